@@ -0,0 +1,323 @@
+package gondex
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// AnnotationInfo represents annotation
+type AnnotationInfo struct {
+	Name string
+	// Params value of the parameters, for repeated parameters the last value
+	Params map[string]string
+	// Values all values of the parameters in the declaration order
+	Values map[string][]string
+	// Args positional arguments of the directive annotations
+	Args []string
+	// Inherited is true for the annotation of the grouped declaration
+	Inherited bool
+
+	pos      token.Pos
+	position token.Position
+}
+
+// Pos position of the annotation comment
+func (a *AnnotationInfo) Pos() token.Pos {
+	return a.pos
+}
+
+// Position file position of the annotation comment
+func (a *AnnotationInfo) Position() token.Position {
+	return a.position
+}
+
+// File file name of the annotation
+func (a *AnnotationInfo) File() string {
+	return a.position.Filename
+}
+
+// Path returns parts of the hierarchical annotation name `kubebuilder:validation:Minimum`
+func (a *AnnotationInfo) Path() []string {
+	return strings.Split(a.Name, ":")
+}
+
+// HasPrefix returns true if the annotation name is the prefix or starts with the prefix and `:`
+func (a *AnnotationInfo) HasPrefix(prefix string) bool {
+	return a.Name == prefix || strings.HasPrefix(a.Name, prefix+":")
+}
+
+// List returns all values of the parameter or nil
+func (a *AnnotationInfo) List(name string) []string {
+	return a.Values[name]
+}
+
+// Flag returns true if the parameter is set as flag or has value true
+func (a *AnnotationInfo) Flag(name string) bool {
+	v, e := a.Params[name]
+	if !e {
+		return false
+	}
+	b, err := strconv.ParseBool(v)
+	return err == nil && b
+}
+
+// annotated ordered list of the element annotations
+type annotated struct {
+	annotations []*AnnotationInfo
+}
+
+// Annotations returns copy of the list of annotations in the declaration order or empty list
+func (a *annotated) Annotations() []*AnnotationInfo {
+	return append([]*AnnotationInfo{}, a.annotations...)
+}
+
+// AnnotationsPrefixed returns all annotations with the hierarchical name prefix in the declaration order.
+// The prefix `kubebuilder:validation` matches `kubebuilder:validation:Minimum` but not `kubebuilder:validations`.
+func (a *annotated) AnnotationsPrefixed(prefix string) []*AnnotationInfo {
+	var result []*AnnotationInfo
+	for _, anno := range a.annotations {
+		if anno.HasPrefix(prefix) {
+			result = append(result, anno)
+		}
+	}
+	return result
+}
+
+// AnnotationsNamed returns all annotations with the name in the declaration order
+func (a *annotated) AnnotationsNamed(name string) []*AnnotationInfo {
+	var result []*AnnotationInfo
+	for _, anno := range a.annotations {
+		if anno.Name == name {
+			result = append(result, anno)
+		}
+	}
+	return result
+}
+
+// Annotation returns first annotation by name or nil
+func (a *annotated) Annotation(name string) *AnnotationInfo {
+	for _, anno := range a.annotations {
+		if anno.Name == name {
+			return anno
+		}
+	}
+	return nil
+}
+
+// annotationNames returns unique names of the annotations in the declaration order
+func annotationNames(annotations []*AnnotationInfo) []string {
+	names := []string{}
+	seen := map[string]struct{}{}
+	for _, anno := range annotations {
+		if _, e := seen[anno.Name]; e {
+			continue
+		}
+		seen[anno.Name] = struct{}{}
+		names = append(names, anno.Name)
+	}
+	return names
+}
+
+// AnnotationError represents error in the annotation declaration
+type AnnotationError struct {
+	Position   token.Position
+	Annotation string
+	Msg        string
+}
+
+func (e *AnnotationError) Error() string {
+	return fmt.Sprintf("%v: annotation %v: %v", e.Position, e.Annotation, e.Msg)
+}
+
+// createAnnotations this method creates list of annotations info from the comments
+func createAnnotations(comment *ast.CommentGroup, p AnnotationParser, fset *token.FileSet) ([]*AnnotationInfo, error) {
+	// ignore annotation for empty comment
+	if comment == nil {
+		return nil, nil
+	}
+	if len(comment.List) == 0 {
+		return nil, nil
+	}
+
+	result := []*AnnotationInfo{}
+	var errs []error
+
+	for _, c := range comment.List {
+		anno, err := p.Parse(c.Text)
+		if err != nil {
+			e := &AnnotationError{Position: fset.Position(c.Slash), Msg: err.Error()}
+			var syntaxErr *AnnotationSyntaxError
+			if errors.As(err, &syntaxErr) {
+				e.Position = fset.Position(c.Slash + token.Pos(syntaxErr.Offset))
+				e.Annotation = syntaxErr.Annotation
+				e.Msg = syntaxErr.Msg
+			}
+			errs = append(errs, e)
+			continue
+		}
+		if anno == nil {
+			continue
+		}
+
+		anno.pos = c.Slash
+		anno.position = fset.Position(c.Slash)
+		result = append(result, anno)
+	}
+	return result, errors.Join(errs...)
+}
+
+// astGroupDecl declaration shared by all specifications of the declaration. The annotations
+// of the grouped declaration doc comment are parsed once by the indexer annotation parser
+// and shared by the specifications of the group.
+type astGroupDecl struct {
+	fset        *token.FileSet
+	decl        *ast.GenDecl
+	annotations []*AnnotationInfo
+	err         error
+}
+
+// newAstGroupDecl creates the declaration and parses the doc comment of the grouped declaration
+func newAstGroupDecl(fset *token.FileSet, decl *ast.GenDecl, p AnnotationParser) *astGroupDecl {
+	group := &astGroupDecl{fset: fset, decl: decl}
+	if decl.Lparen.IsValid() {
+		group.annotations, group.err = createAnnotations(decl.Doc, p, fset)
+	}
+	return group
+}
+
+// declAnnotations returns annotations of the type or value specification in the declaration.
+//
+// The annotations of the specification doc and line comment are returned first. For the not grouped
+// declaration `type A struct{}` the declaration doc comment belongs to the specification. For the grouped
+// declaration `type ( A struct{}; B struct{} )` the annotations of the declaration doc comment are inherited
+// by all specifications of the group, except the annotations with the name which the specification already
+// declares. The inherited annotations are marked by AnnotationInfo.Inherited. The declaration doc comment
+// is parsed once by newAstGroupDecl and its errors are returned only for the first specification of the group.
+func declAnnotations(group *astGroupDecl, spec ast.Spec, doc, line *ast.CommentGroup, p AnnotationParser) ([]*AnnotationInfo, error) {
+	if !group.decl.Lparen.IsValid() {
+		doc = group.decl.Doc
+	}
+	result, err := createAnnotations(doc, p, group.fset)
+	tmp, err2 := createAnnotations(line, p, group.fset)
+	result = append(result, tmp...)
+	if !group.decl.Lparen.IsValid() {
+		return result, errors.Join(err, err2)
+	}
+
+	err3 := group.err
+	if group.decl.Specs[0] != spec {
+		err3 = nil
+	}
+	names := map[string]struct{}{}
+	for _, a := range result {
+		names[a.Name] = struct{}{}
+	}
+	for _, a := range group.annotations {
+		if _, e := names[a.Name]; e {
+			continue
+		}
+		c := *a
+		c.Params, c.Values, c.Args = maps.Clone(a.Params), maps.Clone(a.Values), slices.Clone(a.Args)
+		c.Inherited = true
+		result = append(result, &c)
+	}
+	return result, errors.Join(err, err2, err3)
+}
+
+// isAnnotation returns true if the comment is annotation
+func isAnnotation(text string, p AnnotationParser) bool {
+	anno, err := p.Parse(text)
+	return anno != nil || err != nil
+}
+
+// parseAnnotationParams parse the annotation parameters `key=value key="quoted value" flag`.
+// The bare key without value is a boolean flag and repeated keys produce the list of values.
+// Returns offset of the error in the text and the error.
+func parseAnnotationParams(anno *AnnotationInfo, text string) (int, error) {
+	i := 0
+	for {
+		// skip spaces
+		for i < len(text) && unicode.IsSpace(rune(text[i])) {
+			i++
+		}
+		if i >= len(text) {
+			return 0, nil
+		}
+
+		// parameter name
+		start := i
+		for i < len(text) && !unicode.IsSpace(rune(text[i])) && text[i] != '=' {
+			if text[i] == '"' || text[i] == '`' {
+				return i, fmt.Errorf("unexpected quote in parameter name")
+			}
+			i++
+		}
+		name := text[start:i]
+		if len(name) == 0 {
+			return i, fmt.Errorf("missing parameter name")
+		}
+
+		// flag parameter
+		if i >= len(text) || text[i] != '=' {
+			anno.add(name, "true")
+			continue
+		}
+
+		// parameter value
+		i++
+		if i < len(text) && (text[i] == '"' || text[i] == '`') {
+			end, err := quotedEnd(text, i)
+			if err != nil {
+				return i, err
+			}
+			value, err := strconv.Unquote(text[i:end])
+			if err != nil {
+				return i, fmt.Errorf("invalid quoted value of the parameter %v: %v", name, err)
+			}
+			if end < len(text) && !unicode.IsSpace(rune(text[end])) {
+				return end, fmt.Errorf("missing space after the value of the parameter %v", name)
+			}
+			anno.add(name, value)
+			i = end
+			continue
+		}
+
+		start = i
+		for i < len(text) && !unicode.IsSpace(rune(text[i])) {
+			if text[i] == '"' || text[i] == '`' {
+				return i, fmt.Errorf("unexpected quote in the value of the parameter %v", name)
+			}
+			i++
+		}
+		anno.add(name, text[start:i])
+	}
+}
+
+// quotedEnd returns end index of the quoted string starting at the index
+func quotedEnd(text string, start int) (int, error) {
+	quote := text[start]
+	for i := start + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated quoted string")
+}
+
+// add adds the parameter value
+func (a *AnnotationInfo) add(name, value string) {
+	a.Params[name] = value
+	a.Values[name] = append(a.Values[name], value)
+}
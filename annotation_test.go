@@ -0,0 +1,199 @@
+package gondex
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnnotationParams(t *testing.T) {
+	tests := []struct {
+		text   string
+		values map[string][]string
+		err    string
+	}{
+		{text: "", values: map[string][]string{}},
+		{text: " a=1 b=2", values: map[string][]string{"a": {"1"}, "b": {"2"}}},
+		{text: " flag", values: map[string][]string{"flag": {"true"}}},
+		{text: " name=\"hello world\" flag", values: map[string][]string{"name": {"hello world"}, "flag": {"true"}}},
+		{text: " name=\"say \\\"hi\\\"\"", values: map[string][]string{"name": {"say \"hi\""}}},
+		{text: " name=`raw \\n`", values: map[string][]string{"name": {"raw \\n"}}},
+		{text: " tag=a tag=b\ttag=c", values: map[string][]string{"tag": {"a", "b", "c"}}},
+		{text: " empty= x=1", values: map[string][]string{"empty": {""}, "x": {"1"}}},
+		{text: " name=\"unterminated", err: "unterminated quoted string"},
+		{text: " name=\"a\"b", err: "missing space"},
+		{text: " =value", err: "missing parameter name"},
+		{text: " na\"me=1", err: "unexpected quote"},
+		{text: " name=a\"b", err: "unexpected quote"},
+	}
+
+	for _, test := range tests {
+		anno := &AnnotationInfo{Name: "test", Params: map[string]string{}, Values: map[string][]string{}}
+		_, err := parseAnnotationParams(anno, test.text)
+		if len(test.err) > 0 {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				panic(fmt.Errorf("%q expected error %v but was %v", test.text, test.err, err))
+			}
+			continue
+		}
+		if err != nil {
+			panic(fmt.Errorf("%q unexpected error %v", test.text, err))
+		}
+		if !reflect.DeepEqual(anno.Values, test.values) {
+			panic(fmt.Errorf("%q expected values %v but was %v", test.text, test.values, anno.Values))
+		}
+	}
+}
+
+func TestAnnotationError(t *testing.T) {
+	indexer := CreateDefaultIndexer()
+	_, err := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/annotations")
+
+	var annoErr *AnnotationError
+	if !errors.As(err, &annoErr) {
+		panic(fmt.Errorf("annotation error expected but was %v", err))
+	}
+	if !strings.HasSuffix(annoErr.Position.Filename, "annotations.go") || annoErr.Position.Line != 7 || annoErr.Position.Column != 21 {
+		panic(fmt.Errorf("wrong annotation error position %v", annoErr.Position))
+	}
+
+	s := indexer.Struct("github.com/go-gluon/gondex/internal/test/annotations.Route")
+	if s == nil {
+		panic(fmt.Errorf("struct with annotations not found"))
+	}
+	a := s.Annotation("test:route")
+	if a.Params["path"] != "/hello world" || !a.Flag("secure") || len(a.List("method")) != 2 {
+		panic(fmt.Errorf("wrong annotation parameters %v", a.Values))
+	}
+	if s.Annotation("test:invalid") != nil {
+		panic(fmt.Errorf("invalid annotation found"))
+	}
+}
+
+func TestRepeatedAnnotations(t *testing.T) {
+	indexer := CreateDefaultIndexer()
+	_, _ = indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/annotations")
+
+	s := indexer.Struct("github.com/go-gluon/gondex/internal/test/annotations.Multi")
+	names := []string{}
+	for _, a := range s.Annotations() {
+		names = append(names, a.Name)
+	}
+	if strings.Join(names, ",") != "test:route,test:other,test:route" {
+		panic(fmt.Errorf("wrong order of annotations %v", names))
+	}
+
+	routes := s.AnnotationsNamed("test:route")
+	if len(routes) != 2 || routes[0].Params["path"] != "/a" || routes[1].Params["path"] != "/b" {
+		panic(fmt.Errorf("wrong repeated annotations %v", routes))
+	}
+	if s.Annotation("test:route") != routes[0] {
+		panic(fmt.Errorf("annotation does not return first annotation"))
+	}
+
+	count := 0
+	for _, item := range indexer.FindStructsByAnnotation("test:route") {
+		if item == s {
+			count++
+		}
+	}
+	if count != 1 {
+		panic(fmt.Errorf("struct with repeated annotation found %v times", count))
+	}
+}
+
+func TestGroupedAnnotations(t *testing.T) {
+	indexer := CreateDefaultIndexer()
+	if _, e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/grouped"); e != nil {
+		panic(e)
+	}
+
+	prefix := "github.com/go-gluon/gondex/internal/test/grouped."
+	annotations := func(items []*AnnotationInfo) string {
+		result := []string{}
+		for _, a := range items {
+			result = append(result, fmt.Sprintf("%v%v:%v", a.Name, a.Params, a.Inherited))
+		}
+		return strings.Join(result, ",")
+	}
+
+	result := map[string]string{
+		"A": annotations(indexer.Struct(prefix + "A").Annotations()),
+		"B": annotations(indexer.Struct(prefix + "B").Annotations()),
+		"C": annotations(indexer.Interface(prefix + "C").Annotations()),
+		"D": annotations(indexer.Struct(prefix + "D").Annotations()),
+	}
+	expected := map[string]string{
+		"A": "test:entitymap[table:a]:false,test:groupmap[name:models]:true",
+		"B": "test:groupmap[name:b]:false",
+		"C": "test:groupmap[name:models]:true",
+		"D": "test:singlemap[]:false",
+	}
+	if !reflect.DeepEqual(result, expected) {
+		panic(fmt.Errorf("wrong grouped annotations %v", result))
+	}
+
+	if len(indexer.FindStructsByAnnotation("test:group")) != 2 || len(indexer.FindInterfacesByAnnotation("test:group")) != 1 {
+		panic(fmt.Errorf("wrong elements by group annotation"))
+	}
+}
+
+// countingParser counts the parsed comments
+type countingParser struct {
+	AnnotationParser
+	count map[string]int
+}
+
+func (p *countingParser) Parse(text string) (*AnnotationInfo, error) {
+	p.count[text]++
+	return p.AnnotationParser.Parse(text)
+}
+
+func TestGroupedAnnotationsParsedOnce(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "grouped.go", `package grouped
+
+//test:group name=models
+//test:broken name="models
+type (
+	A struct{}
+	B struct{}
+	C struct{}
+)
+`, parser.ParseComments)
+	if err != nil {
+		panic(err)
+	}
+
+	p := &countingParser{AnnotationParser: NewDefaultAnnotationParser(nil), count: map[string]int{}}
+	decl := file.Decls[0].(*ast.GenDecl)
+	group := newAstGroupDecl(fset, decl, p)
+	var errs []error
+	var inherited []*AnnotationInfo
+	for _, spec := range decl.Specs {
+		ts := spec.(*ast.TypeSpec)
+		items, err := declAnnotations(group, ts, ts.Doc, ts.Comment, p)
+		if len(items) != 1 || !items[0].Inherited {
+			panic(fmt.Errorf("wrong inherited annotations of %v %v", ts.Name, items))
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+		inherited = append(inherited, items[0])
+	}
+	if p.count["//test:group name=models"] != 1 {
+		panic(fmt.Errorf("group doc comment parsed %v times", p.count["//test:group name=models"]))
+	}
+	if len(errs) != 1 {
+		panic(fmt.Errorf("group doc comment errors reported %v times", len(errs)))
+	}
+	inherited[0].Params["name"] = "changed"
+	if inherited[1] == inherited[0] || inherited[1].Params["name"] != "models" {
+		panic(fmt.Errorf("inherited annotations are shared %v", inherited[1]))
+	}
+}
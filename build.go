@@ -0,0 +1,336 @@
+package gondex
+
+import (
+	"go/types"
+	"os"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// BuildConfig build configuration of the loaded packages. The build tags and environment
+// are added to the tags and environment of the IndexerConfig.
+type BuildConfig struct {
+	// Name of the build configuration, generated from GOOS, GOARCH and build tags for empty value
+	Name string
+	// BuildTags additional build tags
+	BuildTags []string
+	// GOOS target operating system
+	GOOS string
+	// GOARCH target architecture
+	GOARCH string
+	// Env additional environment variables `KEY=value`
+	Env []string
+}
+
+// String returns name of the build configuration
+func (b *BuildConfig) String() string {
+	if len(b.Name) > 0 {
+		return b.Name
+	}
+	parts := []string{}
+	switch {
+	case len(b.GOOS) > 0 && len(b.GOARCH) > 0:
+		parts = append(parts, b.GOOS+"/"+b.GOARCH)
+	case len(b.GOOS) > 0:
+		parts = append(parts, b.GOOS)
+	case len(b.GOARCH) > 0:
+		parts = append(parts, b.GOARCH)
+	}
+	parts = append(parts, b.BuildTags...)
+	return strings.Join(parts, ",")
+}
+
+// buildInfo build configurations of the element
+type buildInfo struct {
+	element Element
+	// builds indexes of the build configurations in the IndexerConfig.BuildConfigs
+	builds []int
+}
+
+// packagesConfig creates configuration of the packages loading for the build configuration
+func (indexer *Indexer) packagesConfig(build *BuildConfig) *packages.Config {
+	config := indexer.config
+	tags := append([]string{}, config.BuildTags...)
+	env := append([]string{}, config.Env...)
+	goos, goarch := config.GOOS, config.GOARCH
+	if build != nil {
+		tags = append(tags, build.BuildTags...)
+		env = append(env, build.Env...)
+		if len(build.GOOS) > 0 {
+			goos = build.GOOS
+		}
+		if len(build.GOARCH) > 0 {
+			goarch = build.GOARCH
+		}
+	}
+	if len(goos) > 0 {
+		env = append(env, "GOOS="+goos)
+	}
+	if len(goarch) > 0 {
+		env = append(env, "GOARCH="+goarch)
+	}
+
+	// the go command uses only the last -tags flag, the tags of the build flags are merged
+	flags, flagTags := splitTagsFlag(config.BuildFlags)
+	tags = append(flagTags, tags...)
+	if len(tags) > 0 {
+		flags = append(flags, "-tags="+strings.Join(tags, ","))
+	}
+
+	cfg := &packages.Config{
+		Mode:       indexer.mode,
+		Dir:        config.Dir,
+		BuildFlags: flags,
+		Fset:       indexer.fset,
+		Tests:      config.Tests,
+	}
+	if len(env) > 0 {
+		cfg.Env = append(os.Environ(), env...)
+	}
+	return cfg
+}
+
+// splitTagsFlag returns the build flags without the -tags flags and the tags of the removed flags
+func splitTagsFlag(flags []string) ([]string, []string) {
+	result, tags := []string{}, []string{}
+	for i := 0; i < len(flags); i++ {
+		name, value, ok := strings.Cut(flags[i], "=")
+		if name != "-tags" && name != "--tags" {
+			result = append(result, flags[i])
+			continue
+		}
+		if !ok && i+1 < len(flags) {
+			i++
+			value = flags[i]
+		}
+		tags = append(tags, strings.FieldsFunc(value, func(r rune) bool {
+			return r == ',' || r == ' '
+		})...)
+	}
+	return result, tags
+}
+
+// mergePackage creates infos of the objects of the next build configuration
+// which do not exist in the package of the previous build configurations
+func (indexer *Indexer) mergePackage(pkgInfo *PackageInfo, pkg *packages.Package) {
+	indexer.debug("Merge package %v", pkg.ID)
+	indexer.merged = true
+	ast := indexer.processAstInfo(pkg)
+
+	existing := indexedNames(pkgInfo)
+
+	// create infos with the package data and AST of the build configuration
+	data, orig := pkgInfo.data, pkgInfo.ast
+	pkgInfo.data, pkgInfo.ast = pkg, ast
+	indexer.processObjects(pkgInfo, pkg, existing)
+	for _, s := range pkgInfo.structs {
+		s.methods = indexer.mergeMethods(pkgInfo, pkg, s.Name(), s.methods)
+	}
+	for _, n := range pkgInfo.namedTypes {
+		n.methods = indexer.mergeMethods(pkgInfo, pkg, n.Name(), n.methods)
+	}
+	// field annotations of the objects of the build configuration, the errors of the files
+	// indexed by the previous build configurations are already reported
+	files := map[string]struct{}{}
+	for key := range orig.fields {
+		files[key.Filename] = struct{}{}
+	}
+	indexer.processFields(pkgInfo, pkg.PkgPath, ast.fields, files)
+	pkgInfo.data, pkgInfo.ast = data, orig
+
+	// AST declarations of the build configuration
+	mergeMap(orig.functions, ast.functions)
+	mergeMap(orig.methods, ast.methods)
+	mergeMap(orig.types, ast.types)
+	mergeMap(orig.values, ast.values)
+	mergeMap(orig.fields, ast.fields)
+}
+
+// indexedNames returns names of the package level objects indexed by all previous build configurations
+func indexedNames(pkgInfo *PackageInfo) map[string]struct{} {
+	result := map[string]struct{}{}
+	for _, s := range pkgInfo.structs {
+		result[s.Name()] = struct{}{}
+	}
+	for _, f := range pkgInfo.functions {
+		result[f.Name()] = struct{}{}
+	}
+	for _, i := range pkgInfo.interfaces {
+		result[i.Name()] = struct{}{}
+	}
+	for _, n := range pkgInfo.namedTypes {
+		result[n.Name()] = struct{}{}
+	}
+	for _, c := range pkgInfo.consts {
+		result[c.Name()] = struct{}{}
+	}
+	for _, v := range pkgInfo.vars {
+		result[v.Name()] = struct{}{}
+	}
+	return result
+}
+
+// mergeMethods adds the methods of the named type of the build configuration which do not exist
+func (indexer *Indexer) mergeMethods(pkgInfo *PackageInfo, pkg *packages.Package, name string, methods []*MethodInfo) []*MethodInfo {
+	named := scopeNamed(pkg, name)
+	if named == nil {
+		return methods
+	}
+	existing := map[string]struct{}{}
+	for _, m := range methods {
+		existing[m.Name()] = struct{}{}
+	}
+	for i := 0; i < named.NumMethods(); i++ {
+		if _, e := existing[named.Method(i).Name()]; !e {
+			methods = append(methods, indexer.createMethodInfo(pkgInfo, named, named.Method(i)))
+		}
+	}
+	return methods
+}
+
+// mergeMap adds the missing values to the map
+func mergeMap[K comparable, V any](m, values map[K]V) {
+	for k, v := range values {
+		if _, e := m[k]; !e {
+			m[k] = v
+		}
+	}
+}
+
+// sameUniverse returns false if any package path imported by both packages is a different package,
+// the packages are type checked by different build configurations or package variants
+func (indexer *Indexer) sameUniverse(a, b *types.Package) bool {
+	x, y := indexer.universe(a), indexer.universe(b)
+	for path, p := range x {
+		if q, e := y[path]; e && p != q {
+			return false
+		}
+	}
+	return true
+}
+
+// universe returns the package and all imported packages by the package path
+func (indexer *Indexer) universe(pkg *types.Package) map[string]*types.Package {
+	if result, e := indexer.universes[pkg]; e {
+		return result
+	}
+	result := map[string]*types.Package{}
+	queue := []*types.Package{pkg}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if _, e := result[p.Path()]; e {
+			continue
+		}
+		result[p.Path()] = p
+		queue = append(queue, p.Imports()...)
+	}
+	if indexer.universes == nil {
+		indexer.universes = map[*types.Package]map[string]*types.Package{}
+	}
+	indexer.universes[pkg] = result
+	return result
+}
+
+// recordBuild records the current build configuration of the package elements which exist
+// in the package of the build configuration
+func (indexer *Indexer) recordBuild(pkgInfo *PackageInfo, pkg *packages.Package) {
+	scope := pkg.Types.Scope()
+	exists := func(name string) bool {
+		return scope.Lookup(name) != nil
+	}
+	for _, s := range pkgInfo.structs {
+		indexer.recordNamedBuild(pkg, s, s.methods)
+	}
+	for _, n := range pkgInfo.namedTypes {
+		indexer.recordNamedBuild(pkg, n, n.methods)
+	}
+	for _, i := range pkgInfo.interfaces {
+		if exists(i.Name()) {
+			indexer.recordElementBuild(i)
+		}
+	}
+	for _, f := range pkgInfo.functions {
+		if exists(f.Name()) {
+			indexer.recordElementBuild(f)
+		}
+	}
+	for _, c := range pkgInfo.consts {
+		if exists(c.Name()) {
+			indexer.recordElementBuild(c)
+		}
+	}
+	for _, v := range pkgInfo.vars {
+		if exists(v.Name()) {
+			indexer.recordElementBuild(v)
+		}
+	}
+}
+
+// recordNamedBuild records the current build configuration of the named type and methods
+func (indexer *Indexer) recordNamedBuild(pkg *packages.Package, e Element, methods []*MethodInfo) {
+	named := scopeNamed(pkg, e.Name())
+	if named == nil {
+		return
+	}
+	indexer.recordElementBuild(e)
+	names := map[string]struct{}{}
+	for i := 0; i < named.NumMethods(); i++ {
+		names[named.Method(i).Name()] = struct{}{}
+	}
+	for _, m := range methods {
+		if _, ok := names[m.Name()]; ok {
+			indexer.recordElementBuild(m)
+		}
+	}
+}
+
+// recordElementBuild records the current build configuration of the element
+func (indexer *Indexer) recordElementBuild(e Element) {
+	info := indexer.builds[e.Id()]
+	if info == nil {
+		info = &buildInfo{element: e}
+		indexer.builds[e.Id()] = info
+	}
+	if len(info.builds) == 0 || info.builds[len(info.builds)-1] != indexer.buildIndex {
+		info.builds = append(info.builds, indexer.buildIndex)
+	}
+}
+
+// scopeNamed returns the named type of the package scope or nil
+func scopeNamed(pkg *packages.Package, name string) *types.Named {
+	obj, ok := pkg.Types.Scope().Lookup(name).(*types.TypeName)
+	if !ok || obj.IsAlias() {
+		return nil
+	}
+	named, _ := obj.Type().(*types.Named)
+	return named
+}
+
+// BuildConfigsOf returns names of the build configurations which contain the element,
+// nil without the build configurations
+func (indexer *Indexer) BuildConfigsOf(id string) []string {
+	info := indexer.builds[id]
+	if info == nil {
+		return nil
+	}
+	result := make([]string, len(info.builds))
+	for i, index := range info.builds {
+		result[i] = indexer.config.BuildConfigs[index].String()
+	}
+	return result
+}
+
+// FindBuildSpecific find all elements which do not exist in all build configurations.
+// The build configurations are counted by the index, the names of the configurations could be the same.
+func (indexer *Indexer) FindBuildSpecific() []Element {
+	result := []Element{}
+	for _, info := range indexer.builds {
+		if len(info.builds) < len(indexer.config.BuildConfigs) {
+			result = append(result, info.element)
+		}
+	}
+	SortById(result)
+	return result
+}
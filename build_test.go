@@ -0,0 +1,196 @@
+package gondex
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"testing"
+)
+
+func TestBuildConfig(t *testing.T) {
+	prefix := "github.com/go-gluon/gondex/internal/test/build."
+	tests := []struct {
+		name     string
+		config   func(config *IndexerConfig)
+		expected []string
+	}{
+		{name: "linux", config: func(config *IndexerConfig) { config.GOOS = "linux" }, expected: []string{"Common", "Linux"}},
+		{name: "windows", config: func(config *IndexerConfig) { config.GOOS = "windows" }, expected: []string{"Common", "Windows"}},
+		{name: "tags", config: func(config *IndexerConfig) {
+			config.GOOS = "linux"
+			config.BuildTags = []string{"integration"}
+		}, expected: []string{"Common", "Integration", "Linux"}},
+		{name: "env", config: func(config *IndexerConfig) {
+			config.Env = []string{"GOOS=windows"}
+			config.BuildFlags = []string{"-tags=integration"}
+		}, expected: []string{"Common", "Integration", "Windows"}},
+		{name: "flags and tags", config: func(config *IndexerConfig) {
+			config.GOOS = "linux"
+			config.BuildFlags = []string{"-tags=integration"}
+			config.BuildTags = []string{"other"}
+		}, expected: []string{"Common", "Integration", "Linux"}},
+	}
+
+	for _, test := range tests {
+		config := CreateDefaultConfig()
+		test.config(config)
+		indexer := CreateIndexer(config)
+		if _, e := indexer.LoadPattern(prefix[:len(prefix)-1]); e != nil {
+			panic(e)
+		}
+		if result := ids(indexer.SortedStructs()); !reflect.DeepEqual(result, prefixed(prefix, test.expected...)) {
+			panic(fmt.Errorf("%v: expected structs %v but was %v", test.name, test.expected, result))
+		}
+	}
+
+	// tags of the build flags are merged to the single -tags flag
+	config := CreateDefaultConfig()
+	config.BuildFlags = []string{"-v", "-tags", "a b", "--tags=c,d"}
+	config.BuildTags = []string{"e"}
+	flags := CreateIndexer(config).packagesConfig(&BuildConfig{BuildTags: []string{"f"}}).BuildFlags
+	if !reflect.DeepEqual(flags, []string{"-v", "-tags=a,b,c,d,e,f"}) {
+		panic(fmt.Errorf("wrong build flags %v", flags))
+	}
+
+	// working directory of the go command
+	config = CreateDefaultConfig()
+	config.Dir = "internal/test/build"
+	config.GOOS = "linux"
+	indexer := CreateIndexer(config)
+	if _, e := indexer.LoadPattern("."); e != nil {
+		panic(e)
+	}
+	if indexer.Struct(prefix+"Linux") == nil {
+		panic(fmt.Errorf("package of the working directory not loaded"))
+	}
+}
+
+func TestBuildConfigs(t *testing.T) {
+	prefix := "github.com/go-gluon/gondex/internal/test/build."
+	config := CreateDefaultConfig()
+	config.BuildConfigs = []*BuildConfig{
+		{GOOS: "linux", GOARCH: "amd64"},
+		{Name: "windows", GOOS: "windows", BuildTags: []string{"integration"}},
+	}
+	indexer := CreateIndexer(config)
+	if _, e := indexer.LoadPattern(prefix[:len(prefix)-1]); e != nil {
+		panic(e)
+	}
+
+	if result := ids(indexer.SortedStructs()); !reflect.DeepEqual(result, prefixed(prefix, "Common", "Integration", "Linux", "Windows")) {
+		panic(fmt.Errorf("wrong merged structs %v", result))
+	}
+	builds := map[string][]string{
+		"Common":          {"linux/amd64", "windows"},
+		"Common.Name":     {"linux/amd64", "windows"},
+		"Common.Platform": {"linux/amd64"},
+		"Linux":           {"linux/amd64"},
+		"Windows":         {"windows"},
+		"Integration":     {"windows"},
+	}
+	for name, expected := range builds {
+		if result := indexer.BuildConfigsOf(prefix + name); !reflect.DeepEqual(result, expected) {
+			panic(fmt.Errorf("%v: expected build configs %v but was %v", name, expected, result))
+		}
+	}
+	if result := ids(indexer.FindBuildSpecific()); !reflect.DeepEqual(result, prefixed(prefix, "Common.Platform", "Integration", "Linux", "Windows", "Windows.Next", "Windows.Read")) {
+		panic(fmt.Errorf("wrong build specific elements %v", result))
+	}
+
+	// merged elements
+	windows := indexer.Struct(prefix + "Windows")
+	if windows.Annotation("test:windows") == nil || windows.Doc().Text() != "Windows struct only for windows\n" || windows.Position().Line != 10 {
+		panic(fmt.Errorf("wrong merged struct %v %v", windows.Annotations(), windows.Position()))
+	}
+	if len(indexer.FindStructsByAnnotation("test:windows")) != 1 || len(indexer.FindStructsByAnnotation("test:linux")) != 1 {
+		panic(fmt.Errorf("merged struct annotations not indexed"))
+	}
+	// field of the shared file walked from the struct of the second build configuration
+	common := windows.FieldStructInfo().Fields()["Common"]
+	named := common.Type().(*types.Named)
+	label := common.FieldStructInfo(named, named.Underlying().(*types.Struct)).Fields()["Label"]
+	if label.Annotation("test:label") == nil {
+		panic(fmt.Errorf("field annotations of the second build configuration not indexed %v", label.Annotations()))
+	}
+	if len(indexer.Struct(prefix+"Common").Methods()) != 2 {
+		panic(fmt.Errorf("wrong merged methods %v", indexer.Struct(prefix+"Common").Methods()))
+	}
+
+	// implementation of the other build configuration is matched by the method signatures
+	if result := ids(indexer.FindImplementations(prefix + "Reader")); !reflect.DeepEqual(result, prefixed(prefix, "Windows")) {
+		panic(fmt.Errorf("wrong implementations of the merged interface %v", result))
+	}
+	if result := ids(indexer.FindImplementations(prefix + "Source")); !reflect.DeepEqual(result, prefixed(prefix, "Windows")) {
+		panic(fmt.Errorf("wrong implementations of the merged generic interface %v", result))
+	}
+	if result := indexer.FindInterfacesImplementedBy(prefix + "Windows"); len(result) != 2 || result[0].Interface.Name() != "Reader" || result[1].Interface.Name() != "Source" {
+		panic(fmt.Errorf("wrong implemented interfaces of the merged struct %v", result))
+	}
+
+	// element of the second build configuration is not created again by the third configuration
+	config = CreateDefaultConfig()
+	config.BuildConfigs = []*BuildConfig{
+		{GOOS: "linux"},
+		{GOOS: "windows"},
+		{GOOS: "windows", BuildTags: []string{"integration"}},
+	}
+	indexer = CreateIndexer(config)
+	if _, e := indexer.LoadPattern(prefix[:len(prefix)-1]); e != nil {
+		panic(e)
+	}
+	structs := 0
+	for _, s := range indexer.Package(prefix[:len(prefix)-1]).structs {
+		if s.Name() == "Windows" {
+			structs++
+		}
+	}
+	if structs != 1 || len(indexer.FindStructsByAnnotation("test:windows")) != 1 {
+		panic(fmt.Errorf("merged struct created %v times", structs))
+	}
+	if result := indexer.BuildConfigsOf(prefix + "Windows"); !reflect.DeepEqual(result, []string{"windows", "windows,integration"}) {
+		panic(fmt.Errorf("wrong build configs of the merged struct %v", result))
+	}
+}
+
+func TestBuildConfigName(t *testing.T) {
+	tests := map[string]*BuildConfig{
+		"linux/amd64":            {GOOS: "linux", GOARCH: "amd64"},
+		"windows":                {GOOS: "windows"},
+		"arm64":                  {GOARCH: "arm64"},
+		"linux,integration":      {GOOS: "linux", BuildTags: []string{"integration"}},
+		"integration":            {BuildTags: []string{"integration"}},
+		"custom":                 {Name: "custom", GOOS: "linux"},
+		"darwin/arm64,cgo,netgo": {GOOS: "darwin", GOARCH: "arm64", BuildTags: []string{"cgo", "netgo"}},
+	}
+	for expected, build := range tests {
+		if build.String() != expected {
+			panic(fmt.Errorf("expected build config name %v but was %v", expected, build.String()))
+		}
+	}
+
+	// build configurations with the same name
+	prefix := "github.com/go-gluon/gondex/internal/test/build."
+	config := CreateDefaultConfig()
+	config.BuildConfigs = []*BuildConfig{
+		{Name: "platform", GOOS: "linux"},
+		{Name: "platform", GOOS: "windows"},
+	}
+	indexer := CreateIndexer(config)
+	if _, e := indexer.LoadPattern(prefix[:len(prefix)-1]); e != nil {
+		panic(e)
+	}
+	if result := indexer.BuildConfigsOf(prefix + "Common"); !reflect.DeepEqual(result, []string{"platform", "platform"}) {
+		panic(fmt.Errorf("wrong build configs of the same name %v", result))
+	}
+	if result := ids(indexer.FindBuildSpecific()); !reflect.DeepEqual(result, prefixed(prefix, "Common.Platform", "Linux", "Windows", "Windows.Next", "Windows.Read")) {
+		panic(fmt.Errorf("wrong build specific elements of the same name %v", result))
+	}
+}
+
+func prefixed(prefix string, names ...string) []string {
+	result := []string{}
+	for _, name := range names {
+		result = append(result, prefix+name)
+	}
+	return result
+}
@@ -0,0 +1,229 @@
+package gondex
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const decodeTag = "anno"
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// DecodeError represents all errors of the annotation decoding
+type DecodeError struct {
+	Position   token.Position
+	Annotation string
+	Errors     []error
+}
+
+func (e *DecodeError) Error() string {
+	msg := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msg[i] = err.Error()
+	}
+	return fmt.Sprintf("%v: annotation %v: %v", e.Position, e.Annotation, strings.Join(msg, "; "))
+}
+
+func (e *DecodeError) Unwrap() []error {
+	return e.Errors
+}
+
+// decodeField field tag options
+type decodeField struct {
+	name     string
+	required bool
+	def      *string
+}
+
+// parseDecodeTag parse the field tag `anno:"name,required,default=value"`.
+// The default value is the rest of the tag and could contain commas. Returns error for the unknown option.
+func parseDecodeTag(field reflect.StructField) (*decodeField, bool, error) {
+	tag := field.Tag.Get(decodeTag)
+	if tag == "-" {
+		return nil, false, nil
+	}
+
+	result := &decodeField{}
+	items := strings.SplitN(tag, ",", 2)
+	result.name = items[0]
+	if len(result.name) == 0 {
+		r, size := utf8.DecodeRuneInString(field.Name)
+		result.name = string(unicode.ToLower(r)) + field.Name[size:]
+	}
+
+	for len(items) > 1 {
+		items = strings.SplitN(items[1], ",", 2)
+		switch {
+		case items[0] == "required":
+			result.required = true
+		case strings.HasPrefix(items[0], "default="):
+			def := strings.TrimPrefix(items[0], "default=")
+			if len(items) > 1 {
+				def = def + "," + items[1]
+				items = items[:1]
+			}
+			result.def = &def
+		default:
+			return nil, false, fmt.Errorf("field %v: unknown tag option %q", field.Name, items[0])
+		}
+	}
+	return result, true, nil
+}
+
+// Decode binds the annotation parameters to the fields of the struct. The parameter
+// name is defined by the field tag `anno:"port,required,default=8080"`, fields without
+// the tag use the field name with lower case first letter and the tag `anno:"-"` skips the field.
+// All field errors are returned in the *DecodeError.
+func (a *AnnotationInfo) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("annotation %v: decode requires non-nil pointer to struct but was %T", a.Name, v)
+	}
+
+	errs := a.decodeStruct(rv.Elem())
+	if len(errs) > 0 {
+		return &DecodeError{Position: a.position, Annotation: a.Name, Errors: errs}
+	}
+	return nil
+}
+
+// decodeStruct decode all fields of the struct
+func (a *AnnotationInfo) decodeStruct(rv reflect.Value) []error {
+	var errs []error
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+
+		// embedded struct
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && len(field.Tag.Get(decodeTag)) == 0 {
+			errs = append(errs, a.decodeStruct(rv.Field(i))...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, ok, err := parseDecodeTag(field)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		values, e := a.Values[tag.name]
+		if !e {
+			switch {
+			case tag.def != nil:
+				values = []string{*tag.def}
+			case tag.required:
+				errs = append(errs, fmt.Errorf("missing required parameter %v", tag.name))
+				continue
+			default:
+				continue
+			}
+		}
+
+		if err := decodeValue(rv.Field(i), values); err != nil {
+			errs = append(errs, fmt.Errorf("parameter %v: %v", tag.name, err))
+		}
+	}
+	return errs
+}
+
+// decodeValue decode the values to the field value
+func decodeValue(rv reflect.Value, values []string) error {
+	if rv.Kind() == reflect.Ptr {
+		v := reflect.New(rv.Type().Elem())
+		if err := decodeValue(v.Elem(), values); err != nil {
+			return err
+		}
+		rv.Set(v)
+		return nil
+	}
+
+	if rv.Kind() == reflect.Slice && !rv.Addr().Type().Implements(textUnmarshalerType) {
+		// single value is a comma separated list
+		if len(values) == 1 {
+			if len(values[0]) == 0 {
+				values = []string{}
+			} else {
+				values = strings.Split(values[0], ",")
+			}
+		}
+		result := reflect.MakeSlice(rv.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := decodeScalar(result.Index(i), value); err != nil {
+				return err
+			}
+		}
+		rv.Set(result)
+		return nil
+	}
+
+	// last value of the repeated parameter, empty list keeps the zero value
+	if len(values) == 0 {
+		return nil
+	}
+	return decodeScalar(rv, values[len(values)-1])
+}
+
+// decodeScalar decode the single value
+func decodeScalar(rv reflect.Value, value string) error {
+	if rv.CanAddr() && rv.Addr().Type().Implements(textUnmarshalerType) {
+		return rv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	}
+
+	if rv.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		rv.SetInt(int64(d))
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 0, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 0, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+	default:
+		return errors.New("not supported type " + rv.Type().String())
+	}
+	return nil
+}
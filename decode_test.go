@@ -0,0 +1,98 @@
+package gondex
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type decodeBase struct {
+	Name string `anno:"name,required"`
+}
+
+type decodeTest struct {
+	decodeBase
+	Port    int           `anno:"port,default=8080"`
+	Secure  bool          `anno:"secure"`
+	Timeout time.Duration `anno:"timeout,default=5s"`
+	Methods []string      `anno:"method"`
+	Codes   []int         `anno:"codes"`
+	Ratio   *float64      `anno:"ratio"`
+	Tags    []string      `anno:"tags,default=a,b"`
+	Limit   uint8
+	Skip    string `anno:"-"`
+}
+
+func TestAnnotationDecode(t *testing.T) {
+	anno := &AnnotationInfo{Name: "test:decode", Params: map[string]string{}, Values: map[string][]string{}}
+	if _, err := parseAnnotationParams(anno, ` name=users secure method=GET method=POST codes=200,201 ratio=0.5 limit=7 skip=x`); err != nil {
+		panic(err)
+	}
+
+	v := &decodeTest{}
+	if err := anno.Decode(v); err != nil {
+		panic(err)
+	}
+	ratio := 0.5
+	expected := &decodeTest{
+		decodeBase: decodeBase{Name: "users"},
+		Port:       8080,
+		Secure:     true,
+		Timeout:    5 * time.Second,
+		Methods:    []string{"GET", "POST"},
+		Codes:      []int{200, 201},
+		Ratio:      &ratio,
+		Tags:       []string{"a", "b"},
+		Limit:      7,
+	}
+	if !reflect.DeepEqual(v, expected) {
+		panic(fmt.Errorf("wrong decoded value %+v", v))
+	}
+
+	anno = &AnnotationInfo{Name: "test:decode", Params: map[string]string{}, Values: map[string][]string{}}
+	if _, err := parseAnnotationParams(anno, ` port=abc limit=300`); err != nil {
+		panic(err)
+	}
+	err := anno.Decode(&decodeTest{})
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) || len(decodeErr.Errors) != 3 {
+		panic(fmt.Errorf("decode errors expected but was %v", err))
+	}
+	if !strings.Contains(err.Error(), "missing required parameter name") {
+		panic(fmt.Errorf("missing required parameter error %v", err))
+	}
+
+	if err := anno.Decode(decodeTest{}); err == nil {
+		panic(fmt.Errorf("decode of non pointer value"))
+	}
+
+	typo := &struct {
+		Port int `anno:"port,requird"`
+	}{}
+	if err := anno.Decode(typo); err == nil || !strings.Contains(err.Error(), `unknown tag option "requird"`) {
+		panic(fmt.Errorf("unknown tag option error expected but was %v", err))
+	}
+}
+
+func TestAnnotationDecodePosition(t *testing.T) {
+	indexer := CreateDefaultIndexer()
+	_, _ = indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/annotations")
+
+	a := indexer.Struct("github.com/go-gluon/gondex/internal/test/annotations.Route").Annotation("test:route")
+	v := &struct {
+		Path   string   `anno:"path"`
+		Method []string `anno:"method"`
+		Secure int      `anno:"secure"`
+	}{}
+	err := a.Decode(v)
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.Position.Line != 3 || len(decodeErr.Errors) != 1 {
+		panic(fmt.Errorf("decode error with position expected but was %v", err))
+	}
+	if v.Path != "/hello world" || len(v.Method) != 2 {
+		panic(fmt.Errorf("wrong decoded value %+v", v))
+	}
+}
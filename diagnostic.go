@@ -0,0 +1,165 @@
+package gondex
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// DiagnosticKind kind of the diagnostic
+type DiagnosticKind int
+
+const (
+	DiagnosticUnknown DiagnosticKind = iota
+	DiagnosticList
+	DiagnosticParse
+	DiagnosticType
+	DiagnosticAnnotation
+)
+
+var diagnosticKindNames = [...]string{
+	DiagnosticUnknown:    "unknown",
+	DiagnosticList:       "list",
+	DiagnosticParse:      "parse",
+	DiagnosticType:       "type",
+	DiagnosticAnnotation: "annotation",
+}
+
+func (k DiagnosticKind) String() string {
+	if k < 0 || int(k) >= len(diagnosticKindNames) {
+		return diagnosticKindNames[DiagnosticUnknown]
+	}
+	return diagnosticKindNames[k]
+}
+
+// Diagnostic problem found during the loading of the packages
+type Diagnostic struct {
+	// Package path of the package
+	Package string
+	// Position source position, invalid position for the errors without position
+	Position token.Position
+	// Msg message of the diagnostic
+	Msg string
+	// Kind kind of the diagnostic
+	Kind DiagnosticKind
+	// Build name of the first build configuration with the diagnostic, empty without the build configurations
+	Build string
+}
+
+func (d Diagnostic) String() string {
+	if d.Position.IsValid() {
+		return fmt.Sprintf("%v: %v", d.Position, d.Msg)
+	}
+	return fmt.Sprintf("%v: %v", d.Package, d.Msg)
+}
+
+// DiagnosticsError error of the loading with the diagnostics
+type DiagnosticsError struct {
+	Diagnostics []Diagnostic
+}
+
+func (e *DiagnosticsError) Error() string {
+	if len(e.Diagnostics) == 1 {
+		return fmt.Sprintf("loading packages with errors: %v", e.Diagnostics[0])
+	}
+	return fmt.Sprintf("loading packages with %v errors: %v", len(e.Diagnostics), e.Diagnostics[0])
+}
+
+// buildDiagnostics sets the name of the current build configuration to the diagnostics and returns
+// the diagnostics which are not reported by the previous build configurations
+func (indexer *Indexer) buildDiagnostics(diagnostics []Diagnostic) []Diagnostic {
+	result := []Diagnostic{}
+	for _, d := range diagnostics {
+		key := fmt.Sprintf("%v\n%v\n%v\n%v", d.Package, d.Kind, d.Position, d.Msg)
+		if i, e := indexer.reported[key]; e && i < indexer.buildIndex {
+			continue
+		}
+		indexer.reported[key] = indexer.buildIndex
+		if indexer.build != nil {
+			d.Build = indexer.build.String()
+		}
+		result = append(result, d)
+	}
+	return result
+}
+
+// packageDiagnostics returns diagnostics of the package. The go list build output `# package`
+// of the package with the parse or type errors is skipped, it repeats the same errors.
+func packageDiagnostics(pkg *packages.Package) []Diagnostic {
+	result := []Diagnostic{}
+	checked := false
+	for _, err := range pkg.Errors {
+		checked = checked || err.Kind == packages.ParseError || err.Kind == packages.TypeError
+	}
+	for _, err := range pkg.Errors {
+		if checked && err.Kind == packages.ListError && strings.HasPrefix(err.Msg, "# "+pkg.PkgPath+"\n") {
+			continue
+		}
+		result = append(result, Diagnostic{
+			Package:  pkg.PkgPath,
+			Position: parsePosition(err.Pos),
+			Msg:      err.Msg,
+			Kind:     packageErrorKind(err.Kind),
+		})
+	}
+	return result
+}
+
+// packageErrorKind returns diagnostic kind of the package error
+func packageErrorKind(kind packages.ErrorKind) DiagnosticKind {
+	switch kind {
+	case packages.ListError:
+		return DiagnosticList
+	case packages.ParseError:
+		return DiagnosticParse
+	case packages.TypeError:
+		return DiagnosticType
+	}
+	return DiagnosticUnknown
+}
+
+// parsePosition parses the position `file:line:column`, `file:line` or `file`
+func parsePosition(pos string) token.Position {
+	if len(pos) == 0 || pos == "-" {
+		return token.Position{}
+	}
+	result := token.Position{Filename: pos}
+	parts := strings.Split(pos, ":")
+	numbers := []int{}
+	for len(parts) > 1 && len(numbers) < 2 {
+		n, err := strconv.Atoi(parts[len(parts)-1])
+		if err != nil {
+			break
+		}
+		numbers = append([]int{n}, numbers...)
+		parts = parts[:len(parts)-1]
+	}
+	if len(numbers) > 0 {
+		result.Filename = strings.Join(parts, ":")
+		result.Line = numbers[0]
+	}
+	if len(numbers) > 1 {
+		result.Column = numbers[1]
+	}
+	return result
+}
+
+// errorDiagnostics returns diagnostics of the error found during the processing of the package
+func errorDiagnostics(pkgPath string, err error) []Diagnostic {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		result := []Diagnostic{}
+		for _, e := range joined.Unwrap() {
+			result = append(result, errorDiagnostics(pkgPath, e)...)
+		}
+		return result
+	}
+	var annoErr *AnnotationError
+	if errors.As(err, &annoErr) {
+		return []Diagnostic{{Package: pkgPath, Position: annoErr.Position, Msg: fmt.Sprintf("annotation %v: %v", annoErr.Annotation, annoErr.Msg), Kind: DiagnosticAnnotation}}
+	}
+	return []Diagnostic{{Package: pkgPath, Msg: err.Error(), Kind: DiagnosticUnknown}}
+}
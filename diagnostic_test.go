@@ -0,0 +1,125 @@
+package gondex
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestParsePosition(t *testing.T) {
+	tests := map[string]token.Position{
+		"":                  {},
+		"-":                 {},
+		"a.go":              {Filename: "a.go"},
+		"a.go:3":            {Filename: "a.go", Line: 3},
+		"/x/a.go:3:7":       {Filename: "/x/a.go", Line: 3, Column: 7},
+		"C:/x/a.go:3:7":     {Filename: "C:/x/a.go", Line: 3, Column: 7},
+		"/x/a.go:12:7:rest": {Filename: "/x/a.go:12:7:rest"},
+	}
+	for pos, expected := range tests {
+		if result := parsePosition(pos); result != expected {
+			panic(fmt.Errorf("%q expected position %v but was %v", pos, expected, result))
+		}
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	prefix := "github.com/go-gluon/gondex/internal/test/testdata/"
+
+	// package errors stop the loading
+	indexer := CreateDefaultIndexer()
+	diagnostics, err := indexer.LoadPattern(prefix+"broken", prefix+"app")
+	var diagErr *DiagnosticsError
+	if !errors.As(err, &diagErr) || len(diagErr.Diagnostics) != 1 || len(indexer.Packages()) != 0 {
+		panic(fmt.Errorf("diagnostics error expected but was %v", err))
+	}
+	d := diagnostics[0]
+	if d.Kind != DiagnosticType || d.Package != prefix+"broken" || !strings.HasSuffix(d.Position.Filename, "broken.go") || d.Position.Line != 8 {
+		panic(fmt.Errorf("wrong package diagnostic %v %v %v", d.Kind, d.Package, d))
+	}
+
+	// tolerant mode indexes packages without errors
+	config := CreateDefaultConfig()
+	config.Tolerant = true
+	indexer = CreateIndexer(config)
+	diagnostics, err = indexer.LoadPattern(prefix+"broken", prefix+"app")
+	if err != nil {
+		panic(err)
+	}
+	if indexer.Package(prefix+"broken") != nil || indexer.Struct(prefix+"good.Good") == nil || indexer.Struct(prefix+"app.Service") == nil {
+		panic(fmt.Errorf("wrong indexed packages %v", indexer.Packages()))
+	}
+	kinds := []string{}
+	for _, d := range diagnostics {
+		kinds = append(kinds, d.Kind.String())
+	}
+	if strings.Join(kinds, ",") != "type,annotation" {
+		panic(fmt.Errorf("wrong diagnostics %v", diagnostics))
+	}
+	d = diagnostics[1]
+	if d.Package != prefix+"app" || d.Position.Line != 5 || d.Msg != "annotation test:app: unterminated quoted string" {
+		panic(fmt.Errorf("wrong annotation diagnostic %v %v", d.Package, d))
+	}
+
+	// the diagnostics of all build configurations are reported once with the build configuration name
+	config = CreateDefaultConfig()
+	config.Tolerant = true
+	config.BuildConfigs = []*BuildConfig{{GOOS: "linux"}, {GOOS: "windows"}}
+	indexer = CreateIndexer(config)
+	diagnostics, err = indexer.LoadPattern(prefix+"broken", prefix+"app")
+	if err != nil || len(diagnostics) != 2 {
+		panic(fmt.Errorf("duplicate diagnostics of the build configurations %v %v", err, diagnostics))
+	}
+	for _, d := range diagnostics {
+		if d.Build != "linux" {
+			panic(fmt.Errorf("wrong build configuration of the diagnostic %v %q", d, d.Build))
+		}
+	}
+
+	// errors of the packages which are not indexed are skipped
+	for name, update := range map[string]func(config *IndexerConfig){
+		"exclude": func(config *IndexerConfig) { config.ExcludePackages = []string{prefix + "broken"} },
+		"depth":   func(config *IndexerConfig) { config.MaxImportDepth = 1 },
+	} {
+		config := CreateDefaultConfig()
+		update(config)
+		indexer = CreateIndexer(config)
+		if diagnostics, err := indexer.LoadPattern(prefix + "uses"); err != nil || len(diagnostics) != 0 {
+			panic(fmt.Errorf("%v: errors of the not indexed package %v", name, err))
+		}
+		if indexer.Struct(prefix+"uses.Uses") == nil {
+			panic(fmt.Errorf("%v: package without errors not indexed", name))
+		}
+	}
+	if _, err := CreateDefaultIndexer().LoadPattern(prefix + "uses"); !errors.As(err, &diagErr) {
+		panic(fmt.Errorf("diagnostics error of the import expected but was %v", err))
+	}
+
+	// annotation errors are also returned as the error
+	_, err = CreateDefaultIndexer().LoadPattern(prefix + "app")
+	var annoErr *AnnotationError
+	if !errors.As(err, &annoErr) {
+		panic(fmt.Errorf("annotation error expected but was %v", err))
+	}
+}
+
+func TestFieldDiagnosticsOrder(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		// the errors of the files in all build configurations are reported once
+		config := CreateDefaultConfig()
+		if i%2 == 1 {
+			config.BuildConfigs = []*BuildConfig{{GOOS: "linux"}, {GOOS: "windows"}}
+		}
+		diagnostics, err := CreateIndexer(config).LoadPattern("github.com/go-gluon/gondex/internal/test/testdata/fields")
+		if err == nil || len(diagnostics) != 6 {
+			panic(fmt.Errorf("field annotation errors expected but was %v", err))
+		}
+		for j, d := range diagnostics {
+			if d.Position.Line != 5+2*j {
+				panic(fmt.Errorf("diagnostics are not in the source order %v", diagnostics))
+			}
+		}
+	}
+}
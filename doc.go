@@ -0,0 +1,74 @@
+package gondex
+
+import (
+	"go/ast"
+	"go/doc/comment"
+)
+
+// DocInfo represents doc comment of the element without the annotation lines
+type DocInfo struct {
+	text string
+}
+
+// createDocInfo creates doc info from the comment groups, the first not empty comment group is used
+func createDocInfo(p AnnotationParser, groups ...*ast.CommentGroup) *DocInfo {
+	for _, group := range groups {
+		if group == nil || len(group.List) == 0 {
+			continue
+		}
+		tmp := &ast.CommentGroup{}
+		for _, c := range group.List {
+			if isAnnotation(c.Text, p) {
+				continue
+			}
+			tmp.List = append(tmp.List, c)
+		}
+		return &DocInfo{text: tmp.Text()}
+	}
+	return &DocInfo{}
+}
+
+// Text plain text of the doc comment
+func (d *DocInfo) Text() string {
+	return d.text
+}
+
+// Empty returns true if there is no doc comment
+func (d *DocInfo) Empty() bool {
+	return len(d.text) == 0
+}
+
+// Comment parsed doc comment with paragraphs, headings, lists and code blocks
+func (d *DocInfo) Comment() *comment.Doc {
+	p := &comment.Parser{}
+	return p.Parse(d.text)
+}
+
+// Paragraphs returns plain text of all paragraphs of the doc comment
+func (d *DocInfo) Paragraphs() []string {
+	result := []string{}
+	for _, block := range d.Comment().Content {
+		if p, ok := block.(*comment.Paragraph); ok {
+			result = append(result, textOf(p.Text))
+		}
+	}
+	return result
+}
+
+// textOf returns plain text of the doc comment text items
+func textOf(items []comment.Text) string {
+	result := ""
+	for _, item := range items {
+		switch t := item.(type) {
+		case comment.Plain:
+			result += string(t)
+		case comment.Italic:
+			result += string(t)
+		case *comment.Link:
+			result += textOf(t.Text)
+		case *comment.DocLink:
+			result += textOf(t.Text)
+		}
+	}
+	return result
+}
@@ -0,0 +1,115 @@
+package gondex
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// packageFilter filter of the indexed packages
+type packageFilter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// createPackageFilter creates package filter from the configuration patterns
+func createPackageFilter(config *IndexerConfig) (*packageFilter, error) {
+	include, err := compilePackagePatterns(config.IncludePackages)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := compilePackagePatterns(config.ExcludePackages)
+	if err != nil {
+		return nil, err
+	}
+	return &packageFilter{include: include, exclude: exclude}, nil
+}
+
+// match returns true if the package path matches include patterns and does not match exclude patterns
+func (f *packageFilter) match(pkgPath string) bool {
+	if len(f.include) > 0 && !matchPackagePatterns(f.include, pkgPath) {
+		return false
+	}
+	return !matchPackagePatterns(f.exclude, pkgPath)
+}
+
+// matchPackagePatterns returns true if any of the pattern matches the package path
+func matchPackagePatterns(patterns []*regexp.Regexp, pkgPath string) bool {
+	for _, p := range patterns {
+		if p.MatchString(pkgPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// compilePackagePatterns compiles the package path glob patterns
+func compilePackagePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	result := []*regexp.Regexp{}
+	for _, pattern := range patterns {
+		r, err := compilePackagePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// compilePackagePattern compiles the package path glob pattern. The `...` matches any string
+// including the empty string and `/`, `*` matches any string without `/` and `?` any character
+// except `/`. The pattern `net/...` matches `net` and all sub packages like the go command.
+func compilePackagePattern(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) == 0 {
+		return nil, fmt.Errorf("empty package pattern")
+	}
+	expr := regexp.QuoteMeta(pattern)
+	if strings.HasSuffix(expr, `/\.\.\.`) {
+		expr = strings.TrimSuffix(expr, `/\.\.\.`) + `(/.*)?`
+	}
+	expr = strings.ReplaceAll(expr, `\.\.\.`, `.*`)
+	expr = strings.ReplaceAll(expr, `\*`, `[^/]*`)
+	expr = strings.ReplaceAll(expr, `\?`, `[^/]`)
+	r, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid package pattern %v: %v", pattern, err)
+	}
+	return r, nil
+}
+
+// skipPackage returns true if the package and its imports are not indexed
+func (indexer *Indexer) skipPackage(pkg *packages.Package) bool {
+	if packageKind(pkg) == PackageTestMain {
+		indexer.debug("Skip test main pkg: %v", pkg.ID)
+		return true
+	}
+	if _, e := indexer.testVariants[pkg.PkgPath]; e && packageKind(pkg) == PackageRegular {
+		indexer.debug("Skip pkg replaced by the test variant: %v", pkg.ID)
+		return true
+	}
+	if indexer.config.SkipGoPackages && isGoPackage(pkg) {
+		indexer.debug("Skip go pkg: %v", pkg.PkgPath)
+		return true
+	}
+	if indexer.config.MainModuleOnly && (pkg.Module == nil || !pkg.Module.Main) {
+		indexer.debug("Skip not main module pkg: %v", pkg.PkgPath)
+		return true
+	}
+	return false
+}
+
+// filterPackage returns false if the package is not indexed by the include and exclude patterns
+// or the package filter. The imports of the filtered package are indexed.
+func (indexer *Indexer) filterPackage(pkg *packages.Package) bool {
+	if indexer.filter != nil && !indexer.filter.match(pkg.PkgPath) {
+		indexer.debug("Skip filtered pkg: %v", pkg.PkgPath)
+		return false
+	}
+	if indexer.config.PackageFilter != nil && !indexer.config.PackageFilter(pkg) {
+		indexer.debug("Skip pkg by filter: %v", pkg.PkgPath)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package gondex
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestPackagePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{pattern: "net/...", path: "net", match: true},
+		{pattern: "net/...", path: "net/http", match: true},
+		{pattern: "net/...", path: "network", match: false},
+		{pattern: "net/*", path: "net/http", match: true},
+		{pattern: "net/*", path: "net/http/httptest", match: false},
+		{pattern: "github.com/.../internal/...", path: "github.com/go-gluon/gondex/internal/test", match: true},
+		{pattern: "github.com/go-gluon/gondex", path: "github.com/go-gluon/gondex/internal", match: false},
+		{pattern: "a/?", path: "a/b", match: true},
+	}
+	for _, test := range tests {
+		r, err := compilePackagePattern(test.pattern)
+		if err != nil {
+			panic(err)
+		}
+		if r.MatchString(test.path) != test.match {
+			panic(fmt.Errorf("pattern %v path %v expected match %v", test.pattern, test.path, test.match))
+		}
+	}
+}
+
+func TestPackageFilter(t *testing.T) {
+	prefix := "github.com/go-gluon/gondex/internal/test/filter/"
+	tests := []struct {
+		name     string
+		config   func(config *IndexerConfig)
+		expected []string
+	}{
+		{name: "default", config: func(config *IndexerConfig) {}, expected: []string{"a", "b", "c"}},
+		{name: "include", config: func(config *IndexerConfig) { config.IncludePackages = []string{prefix + "a", prefix + "b"} }, expected: []string{"a", "b"}},
+		{name: "exclude", config: func(config *IndexerConfig) { config.ExcludePackages = []string{prefix + "b"} }, expected: []string{"a", "c"}},
+		{name: "depth", config: func(config *IndexerConfig) { config.MaxImportDepth = 2 }, expected: []string{"a", "b"}},
+		{name: "callback", config: func(config *IndexerConfig) {
+			config.PackageFilter = func(pkg *packages.Package) bool { return pkg.Name != "c" }
+		}, expected: []string{"a", "b"}},
+		{name: "callback imports", config: func(config *IndexerConfig) {
+			config.PackageFilter = func(pkg *packages.Package) bool { return pkg.Name != "b" }
+		}, expected: []string{"a", "c"}},
+		{name: "include imports", config: func(config *IndexerConfig) { config.IncludePackages = []string{prefix + "c"} }, expected: []string{"c"}},
+		{name: "main module", config: func(config *IndexerConfig) {
+			config.SkipGoPackages = false
+			config.MainModuleOnly = true
+		}, expected: []string{"a", "b", "c"}},
+		{name: "go packages", config: func(config *IndexerConfig) {
+			config.SkipGoPackages = false
+			config.IncludePackages = []string{prefix + "...", "fmt"}
+		}, expected: []string{"a", "b", "c", "fmt"}},
+	}
+
+	for _, test := range tests {
+		config := CreateDefaultConfig()
+		test.config(config)
+		indexer := CreateIndexer(config)
+		if _, e := indexer.LoadPattern(prefix + "a"); e != nil {
+			panic(e)
+		}
+		result := []string{}
+		for id := range indexer.Packages() {
+			if len(id) > len(prefix) && id[:len(prefix)] == prefix {
+				id = id[len(prefix):]
+			}
+			result = append(result, id)
+		}
+		sort.Strings(result)
+		if !reflect.DeepEqual(result, test.expected) {
+			panic(fmt.Errorf("%v: expected packages %v but was %v", test.name, test.expected, result))
+		}
+	}
+
+	config := CreateDefaultConfig()
+	config.IncludePackages = []string{""}
+	if _, e := CreateIndexer(config).LoadPattern(prefix + "a"); e == nil {
+		panic(fmt.Errorf("invalid package pattern error expected"))
+	}
+}
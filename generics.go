@@ -0,0 +1,232 @@
+package gondex
+
+import (
+	"go/types"
+)
+
+// typeParamsOf returns type parameters of the named type as list of types
+func typeParamsOf(named *types.Named) []types.Type {
+	tparams := named.TypeParams()
+	result := make([]types.Type, tparams.Len())
+	for i := 0; i < tparams.Len(); i++ {
+		result[i] = tparams.At(i)
+	}
+	return result
+}
+
+// typeOf returns type of the named type. The generic type is instantiated with
+// its own type parameters, because the uninstantiated generic type can not be
+// used for the implementation check.
+func typeOf(named *types.Named) types.Type {
+	if named.TypeParams().Len() == 0 || named.TypeArgs().Len() > 0 {
+		return named
+	}
+	t, err := types.Instantiate(nil, named, typeParamsOf(named), false)
+	if err != nil {
+		return named
+	}
+	return t
+}
+
+// implementsInterface returns true if the type implements the interface. For generic
+// interfaces the type arguments are inferred from the methods of the type.
+func implementsInterface(t types.Type, interfaceInfo *InterfaceInfo) bool {
+	if interfaceInfo.named.TypeParams().Len() == 0 {
+		if types.Implements(t, interfaceInfo.data) {
+			return true
+		}
+		return crossUniverse(t, interfaceInfo) && implementsBySignature(t, interfaceInfo.data)
+	}
+	targs := inferTypeArgs(t, interfaceInfo)
+	if targs == nil {
+		return false
+	}
+	return implementsInstance(t, interfaceInfo, targs)
+}
+
+// crossUniverse returns true if the type and the interface are from different type universes. The packages
+// of the merged build configurations and the package variants are type checked separately, the same package
+// path is a different package and the types are never identical.
+func crossUniverse(t types.Type, interfaceInfo *InterfaceInfo) bool {
+	indexer := interfaceInfo.pkg.indexer
+	if !indexer.merged {
+		return false
+	}
+	pkg := typePackage(t)
+	return pkg != nil && !indexer.sameUniverse(pkg, interfaceInfo.named.Obj().Pkg())
+}
+
+// typePackage returns package of the named type or the pointer to the named type
+func typePackage(t types.Type) *types.Package {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	if n, ok := types.Unalias(t).(*types.Named); ok {
+		return n.Obj().Pkg()
+	}
+	return nil
+}
+
+// implementsBySignature returns true if the method set of the type contains all methods of the interface,
+// including the methods of the embedded interfaces, with the same signature type string. The types of
+// the different universes are matched by the package path and name, the not exported methods match only
+// in the same package path. The check is used only for the types of the different universes, the same
+// named type of the different build configurations could have a different declaration.
+func implementsBySignature(t types.Type, iface *types.Interface) bool {
+	ms := types.NewMethodSet(t)
+	methods := map[string]types.Object{}
+	for i := 0; i < ms.Len(); i++ {
+		methods[methodSetKey(ms.At(i).Obj())] = ms.At(i).Obj()
+	}
+	for i := 0; i < iface.NumMethods(); i++ {
+		m := iface.Method(i)
+		obj := methods[methodSetKey(m)]
+		if obj == nil || signatureString(obj.Type()) != signatureString(m.Type()) {
+			return false
+		}
+	}
+	return true
+}
+
+// methodSetKey returns name of the exported method or the package path and name of the not exported method
+func methodSetKey(obj types.Object) string {
+	if obj.Exported() || obj.Pkg() == nil {
+		return obj.Name()
+	}
+	return obj.Pkg().Path() + "." + obj.Name()
+}
+
+// signatureString returns type string of the signature with the package paths
+func signatureString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		return p.Path()
+	})
+}
+
+// implementsInstance returns true if the type implements the interface instantiated with type arguments
+func implementsInstance(t types.Type, interfaceInfo *InterfaceInfo, targs []types.Type) bool {
+	iface, err := interfaceInfo.Instantiate(targs...)
+	if err != nil {
+		return false
+	}
+	if types.Implements(t, iface) {
+		return true
+	}
+	return crossUniverse(t, interfaceInfo) && implementsBySignature(t, iface)
+}
+
+// inferTypeArgs infers type arguments of the generic interface from the methods of the type.
+// Returns nil if any of the type arguments could not be inferred.
+func inferTypeArgs(t types.Type, interfaceInfo *InterfaceInfo) []types.Type {
+	tparams := interfaceInfo.named.TypeParams()
+	bind := map[*types.TypeParam]types.Type{}
+	for i := 0; i < tparams.Len(); i++ {
+		bind[tparams.At(i)] = nil
+	}
+
+	for i := 0; i < interfaceInfo.data.NumMethods(); i++ {
+		m := interfaceInfo.data.Method(i)
+		obj, _, _ := types.LookupFieldOrMethod(t, false, m.Pkg(), m.Name())
+		f, ok := obj.(*types.Func)
+		if !ok {
+			return nil
+		}
+		if !unify(m.Type(), f.Type(), bind) {
+			return nil
+		}
+	}
+
+	result := make([]types.Type, tparams.Len())
+	for i := 0; i < tparams.Len(); i++ {
+		result[i] = bind[tparams.At(i)]
+		if result[i] == nil {
+			return nil
+		}
+	}
+	return result
+}
+
+// unify binds the type parameters of x to the corresponding types of y.
+// Returns false if the same type parameter is bound to different types.
+func unify(x, y types.Type, bind map[*types.TypeParam]types.Type) bool {
+	if tp, ok := x.(*types.TypeParam); ok {
+		if b, e := bind[tp]; e {
+			if b == nil {
+				bind[tp] = y
+				return true
+			}
+			return types.Identical(b, y)
+		}
+		return true
+	}
+
+	switch xt := x.(type) {
+	case *types.Pointer:
+		if yt, ok := y.(*types.Pointer); ok {
+			return unify(xt.Elem(), yt.Elem(), bind)
+		}
+	case *types.Slice:
+		if yt, ok := y.(*types.Slice); ok {
+			return unify(xt.Elem(), yt.Elem(), bind)
+		}
+	case *types.Array:
+		if yt, ok := y.(*types.Array); ok {
+			return unify(xt.Elem(), yt.Elem(), bind)
+		}
+	case *types.Chan:
+		if yt, ok := y.(*types.Chan); ok {
+			return unify(xt.Elem(), yt.Elem(), bind)
+		}
+	case *types.Map:
+		if yt, ok := y.(*types.Map); ok {
+			return unify(xt.Key(), yt.Key(), bind) && unify(xt.Elem(), yt.Elem(), bind)
+		}
+	case *types.Signature:
+		if yt, ok := y.(*types.Signature); ok {
+			return unifyTuple(xt.Params(), yt.Params(), bind) && unifyTuple(xt.Results(), yt.Results(), bind)
+		}
+	case *types.Named:
+		if yt, ok := y.(*types.Named); ok && xt.Origin() == yt.Origin() && xt.TypeArgs().Len() == yt.TypeArgs().Len() {
+			for i := 0; i < xt.TypeArgs().Len(); i++ {
+				if !unify(xt.TypeArgs().At(i), yt.TypeArgs().At(i), bind) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// unifyTuple unify all variables of the tuples, the tuples with different length do not unify
+func unifyTuple(x, y *types.Tuple, bind map[*types.TypeParam]types.Type) bool {
+	if x.Len() != y.Len() {
+		return false
+	}
+	for i := 0; i < x.Len(); i++ {
+		if !unify(x.At(i).Type(), y.At(i).Type(), bind) {
+			return false
+		}
+	}
+	return true
+}
+
+// coreStruct returns struct of the type parameter constraint with a single struct type term or nil
+func coreStruct(t *types.TypeParam) (*types.Named, *types.Struct) {
+	iface, ok := t.Constraint().Underlying().(*types.Interface)
+	if !ok || iface.NumEmbeddeds() != 1 {
+		return nil, nil
+	}
+	term := iface.EmbeddedType(0)
+	if u, ok := term.(*types.Union); ok {
+		if u.Len() != 1 {
+			return nil, nil
+		}
+		term = u.Term(0).Type()
+	}
+	named, _ := types.Unalias(term).(*types.Named)
+	s, ok := term.Underlying().(*types.Struct)
+	if !ok {
+		return nil, nil
+	}
+	return named, s
+}
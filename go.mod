@@ -1,11 +1,10 @@
 module github.com/go-gluon/gondex
 
-go 1.17
+go 1.25.0
 
-require golang.org/x/tools v0.1.5
+require golang.org/x/tools v0.44.0
 
 require (
-	golang.org/x/mod v0.4.2 // indirect
-	golang.org/x/sys v0.0.0-20210510120138-977fb7262007 // indirect
-	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
+	golang.org/x/mod v0.35.0 // indirect
+	golang.org/x/sync v0.20.0 // indirect
 )
@@ -1,66 +1,164 @@
 package gondex
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/token"
 	"go/types"
+	"maps"
+	"os"
+	"os/exec"
 	"path"
+	"path/filepath"
 	"reflect"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 
 	"golang.org/x/tools/go/packages"
 )
 
 var (
 	defaultAnnotationRegex = regexp.MustCompile(`^//([0-9A-Za-z_\.]+):([0-9A-Za-z_\.]+)`)
-	goPackages             = map[string]struct{}{}
+	goroot                 string
+	gorootOnce             sync.Once
+	goPackages             = map[string]bool{}
+	goPackagesMutex        sync.Mutex
 )
 
-func init() {
-	pkgs, err := packages.Load(nil, "std", "golang.org/x/...")
-	if err != nil {
-		panic(err)
+// IsGoPackage returns true for the standard library packages and the golang.org/x packages.
+// The standard library packages are detected lazily by the package directory in the GOROOT
+// of the go command. Without GOROOT the package path without the dot in the first path
+// element is the standard library package.
+func IsGoPackage(pkgPath string) bool {
+	if strings.HasPrefix(pkgPath, "golang.org/x/") {
+		return true
 	}
-	for _, pkg := range pkgs {
-		goPackages[pkg.PkgPath] = struct{}{}
+	goPackagesMutex.Lock()
+	defer goPackagesMutex.Unlock()
+	if e, ok := goPackages[pkgPath]; ok {
+		return e
 	}
+	goPackages[pkgPath] = isGorootPackage(pkgPath)
+	return goPackages[pkgPath]
 }
 
-func IsGoPackage(pkgPath string) bool {
-	_, ok := goPackages[pkgPath]
-	return ok
+// isGorootPackage returns true if the package directory exists in the GOROOT source directory
+func isGorootPackage(pkgPath string) bool {
+	dir := gorootDir()
+	if len(dir) == 0 {
+		return isStdPackage(pkgPath)
+	}
+	info, err := os.Stat(filepath.Join(dir, "src", filepath.FromSlash(pkgPath)))
+	return err == nil && info.IsDir()
+}
+
+// gorootDir returns GOROOT of the go command, GOROOT of the build context without the go command
+func gorootDir() string {
+	gorootOnce.Do(func() {
+		if out, err := exec.Command("go", "env", "GOROOT").Output(); err == nil {
+			goroot = strings.TrimSpace(string(out))
+		}
+		if len(goroot) == 0 {
+			goroot = build.Default.GOROOT
+		}
+	})
+	return goroot
+}
+
+// isStdPackage returns true if the first element of the package path has no dot
+func isStdPackage(pkgPath string) bool {
+	return !strings.Contains(strings.Split(pkgPath, "/")[0], ".")
+}
+
+// isGoPackage returns true for the standard library package or the package of the golang.org/x module.
+// The standard library packages have no module, the packages are loaded with the module information
+// for the SkipGoPackages option.
+func isGoPackage(pkg *packages.Package) bool {
+	if pkg.Module != nil {
+		return strings.HasPrefix(pkg.Module.Path, "golang.org/x/")
+	}
+	return isStdPackage(pkg.PkgPath)
 }
 
-// AnnotationInfo represents annotation
-type AnnotationInfo struct {
-	Name   string
-	Params map[string]string
+// Element represents indexed element which could have annotations
+type Element interface {
+	Id() string
+	Name() string
+	Package() *PackageInfo
+	Annotations() []*AnnotationInfo
+	AnnotationsNamed(name string) []*AnnotationInfo
+	AnnotationsPrefixed(prefix string) []*AnnotationInfo
+	Annotation(name string) *AnnotationInfo
+	Pos() token.Pos
+	Position() token.Position
+	File() string
+	Doc() *DocInfo
 }
 
+var (
+	_ Element = (*StructInfo)(nil)
+	_ Element = (*InterfaceInfo)(nil)
+	_ Element = (*InterfaceMethodInfo)(nil)
+	_ Element = (*FunctionInfo)(nil)
+	_ Element = (*MethodInfo)(nil)
+	_ Element = (*NamedTypeInfo)(nil)
+	_ Element = (*ConstInfo)(nil)
+	_ Element = (*VarInfo)(nil)
+	_ Element = (*PackageInfo)(nil)
+)
+
 // StructInfo information about the struct
 type StructInfo struct {
-	pkg         *PackageInfo
-	named       *types.Named
-	data        *types.Struct
-	ast         *AstTypeDecl
-	annotations map[string]*AnnotationInfo
+	pkg     *PackageInfo
+	named   *types.Named
+	data    *types.Struct
+	ast     *AstTypeDecl
+	methods []*MethodInfo
+	annotated
 }
 
 // Implements returns true if struct implments the interface
 func (s *StructInfo) Implements(interfaceInfo *InterfaceInfo) bool {
+	return implements(s.named, interfaceInfo)
+}
+
+// ImplementsValue returns true if the struct value type implements the interface.
+// The struct with pointer receiver methods implements the interface only by the pointer type.
+func (s *StructInfo) ImplementsValue(interfaceInfo *InterfaceInfo) bool {
+	return implementsValue(s.named, interfaceInfo)
+}
+
+// ImplementedInterfaces returns all indexed interfaces implemented by the struct ordered by interface id
+func (s *StructInfo) ImplementedInterfaces() []*ImplementationInfo {
+	return s.pkg.indexer.implementsIndex().implementedBy(s)
+}
+
+// ImplementsInstance returns true if struct implements the generic interface instantiated with the type arguments
+func (s *StructInfo) ImplementsInstance(interfaceInfo *InterfaceInfo, targs ...types.Type) bool {
 	if interfaceInfo == nil {
 		return false
 	}
-	if types.Implements(types.NewPointer(s.named.Obj().Type()), interfaceInfo.data) {
+	t := typeOf(s.named)
+	if implementsInstance(types.NewPointer(t), interfaceInfo, targs) {
 		return true
 	}
-	if types.Implements(s.named.Obj().Type(), interfaceInfo.data) {
+	if implementsInstance(t, interfaceInfo, targs) {
 		return true
 	}
 	return false
 }
 
+// TypeParams type parameters of the generic struct
+func (s *StructInfo) TypeParams() *types.TypeParamList {
+	return s.named.TypeParams()
+}
+
 // Ast ast declaration of the type
 func (s *StructInfo) Ast() *AstTypeDecl {
 	return s.ast
@@ -81,19 +179,32 @@ func (s *StructInfo) Struct() *types.Struct {
 	return s.data
 }
 
-// Annotations returns list of struct annotations or empty list
-func (s *StructInfo) Annotations() map[string]*AnnotationInfo {
-	return s.annotations
+// Name this is the name of the struct
+func (s *StructInfo) Name() string {
+	return s.named.Obj().Name()
+}
+
+// Doc doc comment of the struct without annotations
+func (s *StructInfo) Doc() *DocInfo {
+	if s.ast == nil {
+		return &DocInfo{}
+	}
+	return createDocInfo(s.pkg.indexer.annotationParser(), s.ast.Doc())
 }
 
-// Annotation returns annotation by name or nil
-func (s *StructInfo) Annotation(name string) *AnnotationInfo {
-	return s.annotations[name]
+// Pos position of the struct
+func (s *StructInfo) Pos() token.Pos {
+	return s.named.Obj().Pos()
 }
 
-// Name this is the name of the struct
-func (s *StructInfo) Name() string {
-	return s.named.Obj().Name()
+// Position file position of the struct
+func (s *StructInfo) Position() token.Position {
+	return s.pkg.data.Fset.Position(s.Pos())
+}
+
+// File file name of the struct
+func (s *StructInfo) File() string {
+	return s.Position().Filename
 }
 
 func (s *StructInfo) FieldStructInfo() *FieldStructInfo {
@@ -104,6 +215,7 @@ func (s *StructInfo) FieldStructInfo() *FieldStructInfo {
 		Named:    s.named,
 		Struct:   s.data,
 		Level:    0,
+		indexer:  s.pkg.indexer,
 	}
 }
 
@@ -117,13 +229,28 @@ func (s *StructInfo) Fields(walk FieldStructWalk) {
 	walkStruct(f, walk)
 }
 
+// Methods returns copy of the list of methods declared on the struct
+func (s *StructInfo) Methods() []*MethodInfo {
+	return copyOf(s.methods)
+}
+
+// Method returns method by name or nil
+func (s *StructInfo) Method(name string) *MethodInfo {
+	for _, m := range s.methods {
+		if m.Name() == name {
+			return m
+		}
+	}
+	return nil
+}
+
 // FunctionInfo represents function
 type FunctionInfo struct {
-	pkg         *PackageInfo
-	signature   *types.Signature
-	data        *types.Func
-	decl        *AstFuncDecl
-	annotations map[string]*AnnotationInfo
+	pkg       *PackageInfo
+	signature *types.Signature
+	data      *types.Func
+	decl      *AstFuncDecl
+	annotated
 }
 
 // Decl ast declaration of the type
@@ -131,48 +258,163 @@ func (s *FunctionInfo) Decl() *AstFuncDecl {
 	return s.decl
 }
 
-// Annotation returns annotation by name or nil
-func (s *FunctionInfo) Annotation(name string) *AnnotationInfo {
-	return s.annotations[name]
-}
-
-// Annotations returns list of interface annotations or emtpy list
-func (s *FunctionInfo) Annotations() map[string]*AnnotationInfo {
-	return s.annotations
-}
-
 // Func func type
 func (s *FunctionInfo) Func() *types.Func {
 	return s.data
 }
 
+// TypeParams type parameters of the generic function
+func (s *FunctionInfo) TypeParams() *types.TypeParamList {
+	return s.signature.TypeParams()
+}
+
 // Ast declaration of the type
 func (s *FunctionInfo) Ast() *AstFuncDecl {
 	return s.decl
 }
 
-// Id of the interface
+// Package function package info
+func (s *FunctionInfo) Package() *PackageInfo {
+	return s.pkg
+}
+
+// Id of the function
 func (s *FunctionInfo) Id() string {
-	return s.data.Id()
+	return objectId(s.pkg, s.data)
 }
 
-// Name of the interface
+// Name of the function
 func (s *FunctionInfo) Name() string {
 	return s.data.Name()
 }
 
+// Doc doc comment of the function without annotations
+func (s *FunctionInfo) Doc() *DocInfo {
+	if s.decl == nil {
+		return &DocInfo{}
+	}
+	return createDocInfo(s.pkg.indexer.annotationParser(), s.decl.decl.Doc)
+}
+
+// Pos position of the function
+func (s *FunctionInfo) Pos() token.Pos {
+	return s.data.Pos()
+}
+
+// Position file position of the function
+func (s *FunctionInfo) Position() token.Position {
+	return s.pkg.data.Fset.Position(s.Pos())
+}
+
+// File file name of the function
+func (s *FunctionInfo) File() string {
+	return s.Position().Filename
+}
+
+// ImplementationInfo represents interface implemented by the type
+type ImplementationInfo struct {
+	Interface *InterfaceInfo
+	// Pointer is true if only the pointer type implements the interface
+	Pointer bool
+}
+
+// MethodInfo represents method declared on the named type
+type MethodInfo struct {
+	pkg       *PackageInfo
+	recv      *types.Named
+	pointer   bool
+	signature *types.Signature
+	data      *types.Func
+	decl      *AstFuncDecl
+	annotated
+}
+
+// Package method package info
+func (s *MethodInfo) Package() *PackageInfo {
+	return s.pkg
+}
+
+// Receiver named type of the method receiver
+func (s *MethodInfo) Receiver() *types.Named {
+	return s.recv
+}
+
+// PointerReceiver returns true if method has pointer receiver
+func (s *MethodInfo) PointerReceiver() bool {
+	return s.pointer
+}
+
+// Signature method signature
+func (s *MethodInfo) Signature() *types.Signature {
+	return s.signature
+}
+
+// Func func type
+func (s *MethodInfo) Func() *types.Func {
+	return s.data
+}
+
+// Ast declaration of the method
+func (s *MethodInfo) Ast() *AstFuncDecl {
+	return s.decl
+}
+
+// Id of the method
+func (s *MethodInfo) Id() string {
+	return id(s.pkg, s.recv) + "." + s.data.Name()
+}
+
+// Name of the method
+func (s *MethodInfo) Name() string {
+	return s.data.Name()
+}
+
+// Doc doc comment of the method without annotations
+func (s *MethodInfo) Doc() *DocInfo {
+	if s.decl == nil {
+		return &DocInfo{}
+	}
+	return createDocInfo(s.pkg.indexer.annotationParser(), s.decl.decl.Doc)
+}
+
+// Pos position of the method
+func (s *MethodInfo) Pos() token.Pos {
+	return s.data.Pos()
+}
+
+// Position file position of the method
+func (s *MethodInfo) Position() token.Position {
+	return s.pkg.data.Fset.Position(s.Pos())
+}
+
+// File file name of the method
+func (s *MethodInfo) File() string {
+	return s.Position().Filename
+}
+
 // InterfaceInfo represents interface
 type InterfaceInfo struct {
-	pkg         *PackageInfo
-	named       *types.Named
-	data        *types.Interface
-	ast         *AstTypeDecl
-	annotations map[string]*AnnotationInfo
+	pkg     *PackageInfo
+	named   *types.Named
+	data    *types.Interface
+	ast     *AstTypeDecl
+	methods []*InterfaceMethodInfo
+	annotated
 }
 
-// Annotation returns annotation by name or nil
-func (s *InterfaceInfo) Annotation(name string) *AnnotationInfo {
-	return s.annotations[name]
+// Methods returns copy of the list of methods declared in the interface
+func (s *InterfaceInfo) Methods() []*InterfaceMethodInfo {
+	return copyOf(s.methods)
+}
+
+// Method returns method declared in the interface by name or nil
+func (s *InterfaceInfo) Method(name string) *InterfaceMethodInfo {
+	for _, m := range s.methods {
+		if m.Name() == name {
+			return m
+		}
+	}
+	return nil
 }
 
 // Interface interface type
@@ -180,11 +422,50 @@ func (s *InterfaceInfo) Interface() *types.Interface {
 	return s.data
 }
 
+// TypeParams type parameters of the generic interface
+func (s *InterfaceInfo) TypeParams() *types.TypeParamList {
+	return s.named.TypeParams()
+}
+
+// Extends returns indexed interfaces embedded in the interface
+func (s *InterfaceInfo) Extends() []*InterfaceInfo {
+	result := []*InterfaceInfo{}
+	for i := 0; i < s.data.NumEmbeddeds(); i++ {
+		named, ok := types.Unalias(s.data.EmbeddedType(i)).(*types.Named)
+		if !ok || named.Obj().Pkg() == nil {
+			continue
+		}
+		if e := s.pkg.indexer.cacheI[namedId(named)]; e != nil {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// IsConstraint returns true if the interface is a type constraint which can only be used for type parameters
+func (s *InterfaceInfo) IsConstraint() bool {
+	return !s.data.IsMethodSet()
+}
+
+// Instantiate instantiates the generic interface with the type arguments
+func (s *InterfaceInfo) Instantiate(targs ...types.Type) (*types.Interface, error) {
+	t, err := types.Instantiate(nil, s.named, targs, true)
+	if err != nil {
+		return nil, err
+	}
+	return t.Underlying().(*types.Interface), nil
+}
+
 // Ast declaration of the type
 func (s *InterfaceInfo) Ast() *AstTypeDecl {
 	return s.ast
 }
 
+// Package interface package info
+func (s *InterfaceInfo) Package() *PackageInfo {
+	return s.pkg
+}
+
 // Id of the interface
 func (s *InterfaceInfo) Id() string {
 	return id(s.pkg, s.named)
@@ -195,9 +476,472 @@ func (s *InterfaceInfo) Name() string {
 	return s.named.Obj().Name()
 }
 
-// Annotations returns list of interface annotations or emtpy list
-func (s *InterfaceInfo) Annotations() map[string]*AnnotationInfo {
-	return s.annotations
+// Doc doc comment of the interface without annotations
+func (s *InterfaceInfo) Doc() *DocInfo {
+	if s.ast == nil {
+		return &DocInfo{}
+	}
+	return createDocInfo(s.pkg.indexer.annotationParser(), s.ast.Doc())
+}
+
+// Pos position of the interface
+func (s *InterfaceInfo) Pos() token.Pos {
+	return s.named.Obj().Pos()
+}
+
+// Position file position of the interface
+func (s *InterfaceInfo) Position() token.Position {
+	return s.pkg.data.Fset.Position(s.Pos())
+}
+
+// File file name of the interface
+func (s *InterfaceInfo) File() string {
+	return s.Position().Filename
+}
+
+// InterfaceMethodInfo represents method declared in the interface
+type InterfaceMethodInfo struct {
+	iface *InterfaceInfo
+	data  *types.Func
+	ast   *AstField
+	annotated
+}
+
+// Interface interface of the method
+func (s *InterfaceMethodInfo) Interface() *InterfaceInfo {
+	return s.iface
+}
+
+// Package method package info
+func (s *InterfaceMethodInfo) Package() *PackageInfo {
+	return s.iface.pkg
+}
+
+// Func func type
+func (s *InterfaceMethodInfo) Func() *types.Func {
+	return s.data
+}
+
+// Signature method signature
+func (s *InterfaceMethodInfo) Signature() *types.Signature {
+	return s.data.Type().(*types.Signature)
+}
+
+// Ast declaration of the method
+func (s *InterfaceMethodInfo) Ast() *AstField {
+	return s.ast
+}
+
+// Id of the method
+func (s *InterfaceMethodInfo) Id() string {
+	return s.iface.Id() + "." + s.data.Name()
+}
+
+// Name of the method
+func (s *InterfaceMethodInfo) Name() string {
+	return s.data.Name()
+}
+
+// Doc doc comment of the method without annotations
+func (s *InterfaceMethodInfo) Doc() *DocInfo {
+	if s.ast == nil {
+		return &DocInfo{}
+	}
+	return createDocInfo(s.iface.pkg.indexer.annotationParser(), s.ast.ast.Doc, s.ast.ast.Comment)
+}
+
+// Pos position of the method
+func (s *InterfaceMethodInfo) Pos() token.Pos {
+	return s.data.Pos()
+}
+
+// Position file position of the method
+func (s *InterfaceMethodInfo) Position() token.Position {
+	return s.iface.pkg.data.Fset.Position(s.Pos())
+}
+
+// File file name of the method
+func (s *InterfaceMethodInfo) File() string {
+	return s.Position().Filename
+}
+
+// TypeKind kind of the underlying type of the named type
+type TypeKind int
+
+const (
+	KindUnknown TypeKind = iota
+	KindBasic
+	KindFunc
+	KindSlice
+	KindArray
+	KindMap
+	KindPointer
+	KindChan
+)
+
+var typeKindNames = [...]string{
+	KindUnknown: "unknown",
+	KindBasic:   "basic",
+	KindFunc:    "func",
+	KindSlice:   "slice",
+	KindArray:   "array",
+	KindMap:     "map",
+	KindPointer: "pointer",
+	KindChan:    "chan",
+}
+
+func (k TypeKind) String() string {
+	if k < 0 || int(k) >= len(typeKindNames) {
+		return typeKindNames[KindUnknown]
+	}
+	return typeKindNames[k]
+}
+
+// typeKind returns kind of the type
+func typeKind(t types.Type) TypeKind {
+	switch t.(type) {
+	case *types.Basic:
+		return KindBasic
+	case *types.Signature:
+		return KindFunc
+	case *types.Slice:
+		return KindSlice
+	case *types.Array:
+		return KindArray
+	case *types.Map:
+		return KindMap
+	case *types.Pointer:
+		return KindPointer
+	case *types.Chan:
+		return KindChan
+	}
+	return KindUnknown
+}
+
+// NamedTypeInfo represents named type which is not a struct or interface
+type NamedTypeInfo struct {
+	pkg     *PackageInfo
+	named   *types.Named
+	ast     *AstTypeDecl
+	methods []*MethodInfo
+	enum    *EnumInfo
+	annotated
+}
+
+// Package named type package info
+func (s *NamedTypeInfo) Package() *PackageInfo {
+	return s.pkg
+}
+
+// Named type named
+func (s *NamedTypeInfo) Named() *types.Named {
+	return s.named
+}
+
+// Underlying underlying type of the named type
+func (s *NamedTypeInfo) Underlying() types.Type {
+	return s.named.Underlying()
+}
+
+// Implements returns true if named type implments the interface
+func (s *NamedTypeInfo) Implements(interfaceInfo *InterfaceInfo) bool {
+	return implements(s.named, interfaceInfo)
+}
+
+// ImplementsValue returns true if the value type implements the interface
+func (s *NamedTypeInfo) ImplementsValue(interfaceInfo *InterfaceInfo) bool {
+	return implementsValue(s.named, interfaceInfo)
+}
+
+// ImplementedInterfaces returns all indexed interfaces implemented by the named type ordered by interface id
+func (s *NamedTypeInfo) ImplementedInterfaces() []*ImplementationInfo {
+	return s.pkg.indexer.implementsIndex().implementedBy(s)
+}
+
+// TypeParams type parameters of the generic named type
+func (s *NamedTypeInfo) TypeParams() *types.TypeParamList {
+	return s.named.TypeParams()
+}
+
+// Kind kind of the underlying type
+func (s *NamedTypeInfo) Kind() TypeKind {
+	return typeKind(s.named.Underlying())
+}
+
+// Ast declaration of the type
+func (s *NamedTypeInfo) Ast() *AstTypeDecl {
+	return s.ast
+}
+
+// Methods returns copy of the list of methods declared on the named type
+func (s *NamedTypeInfo) Methods() []*MethodInfo {
+	return copyOf(s.methods)
+}
+
+// Method returns method by name or nil
+func (s *NamedTypeInfo) Method(name string) *MethodInfo {
+	for _, m := range s.methods {
+		if m.Name() == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// Enum returns enum info if there are typed constants of the named type or nil
+func (s *NamedTypeInfo) Enum() *EnumInfo {
+	return s.enum
+}
+
+// Id of the named type
+func (s *NamedTypeInfo) Id() string {
+	return id(s.pkg, s.named)
+}
+
+// Name of the named type
+func (s *NamedTypeInfo) Name() string {
+	return s.named.Obj().Name()
+}
+
+// Doc doc comment of the named type without annotations
+func (s *NamedTypeInfo) Doc() *DocInfo {
+	if s.ast == nil {
+		return &DocInfo{}
+	}
+	return createDocInfo(s.pkg.indexer.annotationParser(), s.ast.Doc())
+}
+
+// Pos position of the named type
+func (s *NamedTypeInfo) Pos() token.Pos {
+	return s.named.Obj().Pos()
+}
+
+// Position file position of the named type
+func (s *NamedTypeInfo) Position() token.Position {
+	return s.pkg.data.Fset.Position(s.Pos())
+}
+
+// File file name of the named type
+func (s *NamedTypeInfo) File() string {
+	return s.Position().Filename
+}
+
+// EnumInfo represents named type with the typed constants declared in the same package
+type EnumInfo struct {
+	named  *NamedTypeInfo
+	values []*EnumValueInfo
+}
+
+// Type named type of the enum
+func (e *EnumInfo) Type() *NamedTypeInfo {
+	return e.named
+}
+
+// Values returns copy of the list of enum values in the declaration order
+func (e *EnumInfo) Values() []*EnumValueInfo {
+	return copyOf(e.values)
+}
+
+// Value returns enum value by constant name or nil
+func (e *EnumInfo) Value(name string) *EnumValueInfo {
+	for _, v := range e.values {
+		if v.Name() == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// Id of the enum
+func (e *EnumInfo) Id() string {
+	return e.named.Id()
+}
+
+// Name of the enum
+func (e *EnumInfo) Name() string {
+	return e.named.Name()
+}
+
+// EnumValueInfo represents typed constant of the enum
+type EnumValueInfo struct {
+	enum *EnumInfo
+	data *types.Const
+	ast  *AstValueDecl
+}
+
+// Enum enum of the value
+func (v *EnumValueInfo) Enum() *EnumInfo {
+	return v.enum
+}
+
+// Const constant type
+func (v *EnumValueInfo) Const() *types.Const {
+	return v.data
+}
+
+// Ast declaration of the constant
+func (v *EnumValueInfo) Ast() *AstValueDecl {
+	return v.ast
+}
+
+// Name name of the constant
+func (v *EnumValueInfo) Name() string {
+	return v.data.Name()
+}
+
+// Value value of the constant
+func (v *EnumValueInfo) Value() constant.Value {
+	return v.data.Val()
+}
+
+// Pos position of the constant
+func (v *EnumValueInfo) Pos() token.Pos {
+	return v.data.Pos()
+}
+
+// Position file position of the constant
+func (v *EnumValueInfo) Position() token.Position {
+	return v.enum.named.pkg.data.Fset.Position(v.data.Pos())
+}
+
+// File file name of the constant
+func (v *EnumValueInfo) File() string {
+	return v.Position().Filename
+}
+
+// Doc doc comment of the constant without annotations
+func (v *EnumValueInfo) Doc() *DocInfo {
+	if v.ast == nil {
+		return &DocInfo{}
+	}
+	return createDocInfo(v.enum.named.pkg.indexer.annotationParser(), v.ast.Doc())
+}
+
+// ConstInfo represents package level constant
+type ConstInfo struct {
+	pkg  *PackageInfo
+	data *types.Const
+	ast  *AstValueDecl
+	annotated
+}
+
+// Package constant package info
+func (s *ConstInfo) Package() *PackageInfo {
+	return s.pkg
+}
+
+// Const constant type
+func (s *ConstInfo) Const() *types.Const {
+	return s.data
+}
+
+// Type type of the constant
+func (s *ConstInfo) Type() types.Type {
+	return s.data.Type()
+}
+
+// Value value of the constant
+func (s *ConstInfo) Value() constant.Value {
+	return s.data.Val()
+}
+
+// Ast declaration of the constant
+func (s *ConstInfo) Ast() *AstValueDecl {
+	return s.ast
+}
+
+// Pos position of the constant
+func (s *ConstInfo) Pos() token.Pos {
+	return s.data.Pos()
+}
+
+// Position file position of the constant
+func (s *ConstInfo) Position() token.Position {
+	return s.pkg.data.Fset.Position(s.data.Pos())
+}
+
+// File file name of the constant
+func (s *ConstInfo) File() string {
+	return s.Position().Filename
+}
+
+// Id of the constant
+func (s *ConstInfo) Id() string {
+	return objectId(s.pkg, s.data)
+}
+
+// Name of the constant
+func (s *ConstInfo) Name() string {
+	return s.data.Name()
+}
+
+// Doc doc comment of the constant without annotations
+func (s *ConstInfo) Doc() *DocInfo {
+	if s.ast == nil {
+		return &DocInfo{}
+	}
+	return createDocInfo(s.pkg.indexer.annotationParser(), s.ast.Doc())
+}
+
+// VarInfo represents package level variable
+type VarInfo struct {
+	pkg  *PackageInfo
+	data *types.Var
+	ast  *AstValueDecl
+	annotated
+}
+
+// Package variable package info
+func (s *VarInfo) Package() *PackageInfo {
+	return s.pkg
+}
+
+// Var variable type
+func (s *VarInfo) Var() *types.Var {
+	return s.data
+}
+
+// Type type of the variable
+func (s *VarInfo) Type() types.Type {
+	return s.data.Type()
+}
+
+// Ast declaration of the variable
+func (s *VarInfo) Ast() *AstValueDecl {
+	return s.ast
+}
+
+// Pos position of the variable
+func (s *VarInfo) Pos() token.Pos {
+	return s.data.Pos()
+}
+
+// Position file position of the variable
+func (s *VarInfo) Position() token.Position {
+	return s.pkg.data.Fset.Position(s.data.Pos())
+}
+
+// File file name of the variable
+func (s *VarInfo) File() string {
+	return s.Position().Filename
+}
+
+// Id of the variable
+func (s *VarInfo) Id() string {
+	return objectId(s.pkg, s.data)
+}
+
+// Name of the variable
+func (s *VarInfo) Name() string {
+	return s.data.Name()
+}
+
+// Doc doc comment of the variable without annotations
+func (s *VarInfo) Doc() *DocInfo {
+	if s.ast == nil {
+		return &DocInfo{}
+	}
+	return createDocInfo(s.pkg.indexer.annotationParser(), s.ast.Doc())
 }
 
 // ModuleInfo struct represents the module information
@@ -219,11 +963,96 @@ func (m *ModuleInfo) Data() *packages.Module {
 
 // PackageInfo struct represents the package information
 type PackageInfo struct {
+	indexer    *Indexer
 	ast        *AstInfo
 	data       *packages.Package
 	structs    []*StructInfo
 	functions  []*FunctionInfo
 	interfaces []*InterfaceInfo
+	namedTypes []*NamedTypeInfo
+	consts     []*ConstInfo
+	vars       []*VarInfo
+	fields     map[token.Position][]*AnnotationInfo
+	kind       PackageKind
+	annotated
+}
+
+// PackageKind kind of the loaded package
+type PackageKind int
+
+const (
+	// PackageRegular regular package, with the in-package test files the package is indexed from the test variant
+	PackageRegular PackageKind = iota
+	// PackageTestVariant in-package test variant of the package without the regular package
+	PackageTestVariant
+	// PackageExternalTest external test package `name_test`
+	PackageExternalTest
+	// PackageTestMain synthesized test main package, which is never indexed
+	PackageTestMain
+)
+
+var packageKindNames = [...]string{
+	PackageRegular:      "regular",
+	PackageTestVariant:  "test variant",
+	PackageExternalTest: "external test",
+	PackageTestMain:     "test main",
+}
+
+func (k PackageKind) String() string {
+	if k < 0 || int(k) >= len(packageKindNames) {
+		return packageKindNames[PackageRegular]
+	}
+	return packageKindNames[k]
+}
+
+// packageKind returns kind of the package. The test packages loaded with the tests have the ID
+// `path [path.test]`, the test variants of the dependencies `dep [path.test]` are regular packages.
+func packageKind(pkg *packages.Package) PackageKind {
+	i := strings.Index(pkg.ID, " [")
+	if i < 0 {
+		if strings.HasSuffix(pkg.PkgPath, ".test") && pkg.Name == "main" {
+			return PackageTestMain
+		}
+		return PackageRegular
+	}
+	test := strings.TrimSuffix(pkg.ID[i+2:], "]")
+	switch test {
+	case pkg.PkgPath + ".test":
+		return PackageTestVariant
+	case strings.TrimSuffix(pkg.PkgPath, "_test") + ".test":
+		return PackageExternalTest
+	}
+	return PackageRegular
+}
+
+// testVariants returns package paths of the loaded regular packages with the in-package test variant
+func testVariants(pkgs []*packages.Package) map[string]struct{} {
+	regular, variants := map[string]struct{}{}, map[string]struct{}{}
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		switch packageKind(pkg) {
+		case PackageRegular:
+			regular[pkg.PkgPath] = struct{}{}
+		case PackageTestVariant:
+			variants[pkg.PkgPath] = struct{}{}
+		}
+	})
+	result := map[string]struct{}{}
+	for path := range variants {
+		if _, e := regular[path]; e {
+			result[path] = struct{}{}
+		}
+	}
+	return result
+}
+
+// IsTestFile returns true if the element is declared in the `_test.go` file
+func IsTestFile(e Element) bool {
+	return strings.HasSuffix(e.File(), "_test.go")
+}
+
+// Kind of the package
+func (p *PackageInfo) Kind() PackageKind {
+	return p.kind
 }
 
 // Data of the package
@@ -231,37 +1060,153 @@ func (p *PackageInfo) Data() *packages.Package {
 	return p.data
 }
 
-// ID of the package
+// Id of the package is the package path, the same for the test variant, see Indexer.Package
 func (p *PackageInfo) Id() string {
-	return p.data.ID
+	return p.data.PkgPath
+}
+
+// Name of the package
+func (p *PackageInfo) Name() string {
+	return p.data.Name
+}
+
+// Files returns file names of the package
+func (p *PackageInfo) Files() []string {
+	return p.data.CompiledGoFiles
+}
+
+// Package returns the package itself
+func (p *PackageInfo) Package() *PackageInfo {
+	return p
+}
+
+// docFile returns the first file with the package doc comment or the first file
+func (p *PackageInfo) docFile() *ast.File {
+	for _, file := range p.data.Syntax {
+		if file.Doc != nil {
+			return file
+		}
+	}
+	if len(p.data.Syntax) > 0 {
+		return p.data.Syntax[0]
+	}
+	return nil
+}
+
+// Pos position of the package clause of the file with the package doc comment
+func (p *PackageInfo) Pos() token.Pos {
+	if file := p.docFile(); file != nil {
+		return file.Package
+	}
+	return token.NoPos
+}
+
+// Position file position of the package clause
+func (p *PackageInfo) Position() token.Position {
+	return p.data.Fset.Position(p.Pos())
+}
+
+// File file name of the package clause
+func (p *PackageInfo) File() string {
+	return p.Position().Filename
+}
+
+// Doc returns the package doc comment without annotations
+func (p *PackageInfo) Doc() *DocInfo {
+	if file := p.docFile(); file != nil {
+		return createDocInfo(p.indexer.annotationParser(), file.Doc)
+	}
+	return &DocInfo{}
 }
 
 type IndexerConfig struct {
+	// DefaultAnnoRegex annotation name regex of the default annotation parser
 	DefaultAnnoRegex *regexp.Regexp
-	DefaultPattern   []string
-	Debug            bool
-	SkipGoPackages   bool
-	Mode             packages.LoadMode
+	// AnnotationParser parser of the annotations, the default annotation parser
+	// with the DefaultAnnoRegex is used if the parser is not set
+	AnnotationParser AnnotationParser
+	// MarkerMode enables the kubebuilder/controller-gen markers `+kubebuilder:validation:Minimum=1`
+	// in addition to the annotation parser. The markers of the type are also read from the comment
+	// group separated by the empty line above the type doc comment.
+	MarkerMode     bool
+	DefaultPattern []string
+	Debug          bool
+	// SkipGoPackages skips the standard library and golang.org/x packages, see IsGoPackage
+	SkipGoPackages bool
+	// IncludePackages glob patterns of the indexed package paths, all packages for empty list.
+	// The pattern `...` matches any string, `*` any string without `/`, `net/...` matches `net` and sub packages.
+	IncludePackages []string
+	// ExcludePackages glob patterns of the skipped package paths, the imports of the skipped package are indexed
+	ExcludePackages []string
+	// MainModuleOnly indexes only the packages of the main module
+	MainModuleOnly bool
+	// MaxImportDepth maximum depth of the indexed imports, the loaded packages have depth 1. Zero is unlimited.
+	MaxImportDepth int
+	// PackageFilter returns false for the packages which are not indexed, the imports of the package are indexed
+	PackageFilter func(pkg *packages.Package) bool
+	// Tolerant indexes the packages without errors and returns the errors as diagnostics
+	Tolerant bool
+	// BuildTags build tags of the loaded packages
+	BuildTags []string
+	// GOOS target operating system of the loaded packages, the current environment for empty value
+	GOOS string
+	// GOARCH target architecture of the loaded packages, the current environment for empty value
+	GOARCH string
+	// Env additional environment variables `KEY=value` of the go command
+	Env []string
+	// Dir working directory of the go command, the current directory for empty value
+	Dir string
+	// BuildFlags additional flags of the go command, the tags of the -tags flag are merged with the build tags
+	BuildFlags []string
+	// Tests loads also the test files, see PackageKind
+	Tests bool
+	// BuildConfigs build configurations which are loaded and merged in the order, see BuildConfig
+	BuildConfigs []*BuildConfig
+	Mode         packages.LoadMode
 }
 
 // Indexer hold the information about the packages and types
 type Indexer struct {
-	mode       packages.LoadMode
-	mainModule *ModuleInfo
-	config     *IndexerConfig
-	packages   []*PackageInfo
-	cacheP     map[string]*PackageInfo
-	cacheI     map[string]*InterfaceInfo
-	cacheS     map[string]*StructInfo
-	cacheA     map[string][]*StructInfo
-	cacheAI    map[string][]*InterfaceInfo
-	cacheM     map[string]*ModuleInfo
+	mode         packages.LoadMode
+	mainModule   *ModuleInfo
+	config       *IndexerConfig
+	packages     []*PackageInfo
+	cacheP       map[string]*PackageInfo
+	cacheI       map[string]*InterfaceInfo
+	cacheS       map[string]*StructInfo
+	cacheN       map[string]*NamedTypeInfo
+	cacheE       map[string]*EnumInfo
+	cacheC       map[string]*ConstInfo
+	cacheV       map[string]*VarInfo
+	cacheA       map[string][]*StructInfo
+	cacheAI      map[string][]*InterfaceInfo
+	cacheAM      map[string][]*MethodInfo
+	cacheAN      map[string][]*NamedTypeInfo
+	cacheAC      map[string][]*ConstInfo
+	cacheAP      map[string][]*PackageInfo
+	cacheAF      map[string][]*FunctionInfo
+	cacheAE      map[string][]Element
+	cacheAV      map[string][]*VarInfo
+	cacheM       map[string]*ModuleInfo
+	implements   *implementsIndex
+	parser       AnnotationParser
+	filter       *packageFilter
+	errors       []error
+	diagnostics  []Diagnostic
+	reported     map[string]int
+	fset         *token.FileSet
+	build        *BuildConfig
+	buildIndex   int
+	builds       map[string]*buildInfo
+	merged       bool
+	universes    map[*types.Package]map[string]*types.Package
+	testVariants map[string]struct{}
 }
 
 // create module info from the package
 func (indexer *Indexer) createModuleInfo(pkg *packages.Package) (*ModuleInfo, bool) {
 
-	if indexer.mode&packages.NeedModule == 0 {
+	if indexer.mode&packages.NeedModule == 0 || pkg.Module == nil {
 		return nil, false
 	}
 
@@ -287,108 +1232,334 @@ func (indexer *Indexer) createPackageInfo(pkg *packages.Package) *PackageInfo {
 	ast := indexer.processAstInfo(pkg)
 
 	p := &PackageInfo{
+		indexer:    indexer,
 		ast:        ast,
 		data:       pkg,
 		structs:    []*StructInfo{},
 		functions:  []*FunctionInfo{},
 		interfaces: []*InterfaceInfo{},
+		namedTypes: []*NamedTypeInfo{},
+		consts:     []*ConstInfo{},
+		vars:       []*VarInfo{},
+		fields:     map[token.Position][]*AnnotationInfo{},
+		kind:       packageKind(pkg),
 	}
 
+	// the test variant replaces the regular package, the importers use types of the regular package
+	if _, e := indexer.testVariants[pkg.PkgPath]; e && p.kind == PackageTestVariant {
+		p.kind = PackageRegular
+		indexer.merged = true
+	}
+
+	// package annotations from all files
+	for _, file := range pkg.Syntax {
+		anno, err := createAnnotations(file.Doc, indexer.annotationParser(), pkg.Fset)
+		indexer.error(pkg.PkgPath, err)
+		p.annotations = append(p.annotations, anno...)
+	}
+	for _, name := range annotationNames(p.annotations) {
+		indexer.cacheAP[name] = append(indexer.cacheAP[name], p)
+	}
+	indexer.indexAnnotations(p)
+
+	// struct fields and interface methods annotations
+	indexer.processFields(p, pkg.PkgPath, ast.fields, nil)
+
 	indexer.cacheP[p.data.PkgPath] = p
 	indexer.packages = append(indexer.packages, p)
 	return p
 }
 
-// createStructInfo creates struct info
-func (indexer *Indexer) createStructInfo(pkg *PackageInfo, named *types.Named, data *types.Struct) *StructInfo {
+// processFields creates annotations of the fields in the source order. The errors of the reported files
+// are skipped, the field declaration with more names `A, B string` reports the errors once.
+func (indexer *Indexer) processFields(p *PackageInfo, pkgPath string, fields map[token.Position]*AstField, reported map[string]struct{}) {
+	parsed := map[*AstField]struct{}{}
+	for _, key := range sortedFields(fields) {
+		f := fields[key]
+		anno, err := f.Annotations(indexer.annotationParser())
+		if _, e := parsed[f]; !e {
+			parsed[f] = struct{}{}
+			if _, e := reported[key.Filename]; !e {
+				indexer.error(pkgPath, err)
+			}
+		}
+		if len(anno) > 0 {
+			p.fields[key] = anno
+		}
+	}
+}
+
+// fieldKey returns the source position of the struct field or interface method. The objects of the build
+// configurations and the package variants are different, the position is the same for all of them.
+func fieldKey(fset *token.FileSet, obj types.Object) token.Position {
+	pos := fset.Position(obj.Pos())
+	pos.Offset = 0
+	return pos
+}
+
+// fieldAnnotations returns annotations of the struct field or interface method
+func (indexer *Indexer) fieldAnnotations(obj types.Object) []*AnnotationInfo {
+	if obj.Pkg() == nil {
+		return nil
+	}
+	pkg := indexer.cacheP[obj.Pkg().Path()]
+	if pkg == nil {
+		return nil
+	}
+	return pkg.fields[fieldKey(indexer.fset, obj)]
+}
+
+// fieldAst returns ast of the struct field or interface method
+func (indexer *Indexer) fieldAst(obj types.Object) *AstField {
+	if obj.Pkg() == nil {
+		return nil
+	}
+	pkg := indexer.cacheP[obj.Pkg().Path()]
+	if pkg == nil {
+		return nil
+	}
+	return pkg.ast.fields[fieldKey(indexer.fset, obj)]
+}
+
+// createStructInfo creates struct info
+func (indexer *Indexer) createStructInfo(pkg *PackageInfo, named *types.Named, data *types.Struct) *StructInfo {
+	name := named.Obj().Name()
+
+	s := &StructInfo{
+		pkg:   pkg,
+		named: named,
+		data:  data,
+		ast:   pkg.ast.types[name],
+	}
+	pkg.structs = append(pkg.structs, s)
+	indexer.cacheS[s.Id()] = s
+	indexer.debug("Struct %v", name)
+	s.methods = indexer.createMethodInfos(pkg, named)
+	if s.ast == nil {
+		return s
+	}
+
+	anno, err := s.ast.Annotations(indexer.annotationParser())
+	indexer.error(pkg.Id(), err)
+	s.annotations = anno
+	for _, name := range annotationNames(anno) {
+		indexer.cacheA[name] = append(indexer.cacheA[name], s)
+	}
+	indexer.indexAnnotations(s)
+
+	return s
+}
+
+// createMethodInfos creates method infos for all methods declared on the named type
+func (indexer *Indexer) createMethodInfos(pkg *PackageInfo, named *types.Named) []*MethodInfo {
+	result := []*MethodInfo{}
+	for i := 0; i < named.NumMethods(); i++ {
+		result = append(result, indexer.createMethodInfo(pkg, named, named.Method(i)))
+	}
+	return result
+}
+
+// createMethodInfo creates method info
+func (indexer *Indexer) createMethodInfo(pkg *PackageInfo, named *types.Named, data *types.Func) *MethodInfo {
+	signature := data.Type().(*types.Signature)
+	_, pointer := signature.Recv().Type().(*types.Pointer)
+
+	m := &MethodInfo{
+		pkg:       pkg,
+		recv:      named,
+		pointer:   pointer,
+		signature: signature,
+		data:      data,
+		decl:      pkg.ast.methods[methodKey(named.Obj().Name(), data.Name())],
+	}
+	indexer.debug("Method %v", m.Id())
+	if m.decl == nil {
+		return m
+	}
+
+	anno, err := m.decl.Annotations(indexer.annotationParser())
+	indexer.error(pkg.Id(), err)
+	m.annotations = anno
+	for _, name := range annotationNames(anno) {
+		indexer.cacheAM[name] = append(indexer.cacheAM[name], m)
+	}
+	indexer.indexAnnotations(m)
+	return m
+}
+
+// createInterfaceInfo creates interface info
+func (indexer *Indexer) createInterfaceInfo(pkg *PackageInfo, named *types.Named, data *types.Interface) *InterfaceInfo {
+	name := named.Obj().Name()
+
+	s := &InterfaceInfo{
+		pkg:   pkg,
+		named: named,
+		data:  data,
+		ast:   pkg.ast.types[name],
+	}
+	pkg.interfaces = append(pkg.interfaces, s)
+	indexer.cacheI[s.Id()] = s
+
+	for i := 0; i < data.NumExplicitMethods(); i++ {
+		m := data.ExplicitMethod(i)
+		mi := &InterfaceMethodInfo{
+			iface:     s,
+			data:      m,
+			ast:       pkg.ast.fields[fieldKey(indexer.fset, m)],
+			annotated: annotated{annotations: pkg.fields[fieldKey(indexer.fset, m)]},
+		}
+		s.methods = append(s.methods, mi)
+		indexer.indexAnnotations(mi)
+	}
+
+	if s.ast == nil {
+		return s
+	}
+
+	anno, err := s.ast.Annotations(indexer.annotationParser())
+	indexer.error(pkg.Id(), err)
+	s.annotations = anno
+	for _, name := range annotationNames(anno) {
+		indexer.cacheAI[name] = append(indexer.cacheAI[name], s)
+	}
+	indexer.indexAnnotations(s)
+	return s
+}
+
+// createNamedTypeInfo creates named type info
+func (indexer *Indexer) createNamedTypeInfo(pkg *PackageInfo, named *types.Named) *NamedTypeInfo {
 	name := named.Obj().Name()
 
-	s := &StructInfo{
-		pkg:         pkg,
-		named:       named,
-		data:        data,
-		ast:         pkg.ast.types[name],
-		annotations: map[string]*AnnotationInfo{},
+	s := &NamedTypeInfo{
+		pkg:   pkg,
+		named: named,
+		ast:   pkg.ast.types[name],
 	}
-	pkg.structs = append(pkg.structs, s)
-	indexer.cacheS[s.Id()] = s
-	indexer.debug("Struct %v", name)
+	pkg.namedTypes = append(pkg.namedTypes, s)
+	indexer.cacheN[s.Id()] = s
+	indexer.debug("Named type %v - %v", name, s.Kind())
+	s.methods = indexer.createMethodInfos(pkg, named)
 	if s.ast == nil {
 		return s
 	}
 
-	anno := s.ast.Annotations(indexer.config.DefaultAnnoRegex)
-	if anno != nil {
-		s.annotations = anno
-		for _, a := range anno {
-			tmp := indexer.cacheA[a.Name]
-			if tmp == nil {
-				tmp = []*StructInfo{}
-			}
-			tmp = append(tmp, s)
-			indexer.cacheA[a.Name] = tmp
-		}
+	anno, err := s.ast.Annotations(indexer.annotationParser())
+	indexer.error(pkg.Id(), err)
+	s.annotations = anno
+	for _, name := range annotationNames(anno) {
+		indexer.cacheAN[name] = append(indexer.cacheAN[name], s)
 	}
-
+	indexer.indexAnnotations(s)
 	return s
 }
 
-// createInterfaceInfo creates interface info
-func (indexer *Indexer) createInterfaceInfo(pkg *PackageInfo, named *types.Named, data *types.Interface) *InterfaceInfo {
-	name := named.Obj().Name()
+// createConstInfo creates constant info
+func (indexer *Indexer) createConstInfo(pkg *PackageInfo, data *types.Const) *ConstInfo {
+	c := &ConstInfo{
+		pkg:  pkg,
+		data: data,
+		ast:  pkg.ast.values[data.Name()],
+	}
+	pkg.consts = append(pkg.consts, c)
+	indexer.cacheC[c.Id()] = c
+	indexer.debug("Const %v", c.Name())
+	if c.ast == nil {
+		return c
+	}
 
-	s := &InterfaceInfo{
-		pkg:         pkg,
-		named:       named,
-		data:        data,
-		ast:         pkg.ast.types[name],
-		annotations: map[string]*AnnotationInfo{},
+	anno, err := c.ast.Annotations(indexer.annotationParser())
+	indexer.error(pkg.Id(), err)
+	c.annotations = anno
+	for _, name := range annotationNames(anno) {
+		indexer.cacheAC[name] = append(indexer.cacheAC[name], c)
 	}
-	pkg.interfaces = append(pkg.interfaces, s)
-	indexer.cacheI[s.Id()] = s
+	indexer.indexAnnotations(c)
+	return c
+}
 
-	if s.ast == nil {
-		return s
+// createVarInfo creates variable info
+func (indexer *Indexer) createVarInfo(pkg *PackageInfo, data *types.Var) *VarInfo {
+	v := &VarInfo{
+		pkg:  pkg,
+		data: data,
+		ast:  pkg.ast.values[data.Name()],
+	}
+	pkg.vars = append(pkg.vars, v)
+	indexer.cacheV[v.Id()] = v
+	indexer.debug("Var %v", v.Name())
+	if v.ast == nil {
+		return v
 	}
 
-	anno := s.ast.Annotations(indexer.config.DefaultAnnoRegex)
-	if anno != nil {
-		s.annotations = anno
-		for _, a := range anno {
-			tmp := indexer.cacheAI[a.Name]
-			if tmp == nil {
-				tmp = []*InterfaceInfo{}
-			}
-			tmp = append(tmp, s)
-			indexer.cacheAI[a.Name] = tmp
+	anno, err := v.ast.Annotations(indexer.annotationParser())
+	indexer.error(pkg.Id(), err)
+	v.annotations = anno
+	for _, name := range annotationNames(anno) {
+		indexer.cacheAV[name] = append(indexer.cacheAV[name], v)
+	}
+	indexer.indexAnnotations(v)
+	return v
+}
+
+// createEnumInfos creates enum infos from the typed constants of the package named types
+func (indexer *Indexer) createEnumInfos(pkg *PackageInfo, consts []*types.Const) {
+	for _, c := range consts {
+		named, ok := c.Type().(*types.Named)
+		if !ok || named.Obj().Pkg() != pkg.data.Types {
+			continue
+		}
+		n := indexer.cacheN[id(pkg, named)]
+		if n == nil {
+			continue
 		}
+		if n.enum == nil {
+			n.enum = &EnumInfo{named: n, values: []*EnumValueInfo{}}
+			indexer.cacheE[n.Id()] = n.enum
+		}
+		n.enum.values = append(n.enum.values, &EnumValueInfo{
+			enum: n.enum,
+			data: c,
+			ast:  pkg.ast.values[c.Name()],
+		})
+	}
+
+	// keep the declaration order of the constants
+	for _, n := range pkg.namedTypes {
+		if n.enum == nil {
+			continue
+		}
+		sort.SliceStable(n.enum.values, func(i, j int) bool {
+			return n.enum.values[i].data.Pos() < n.enum.values[j].data.Pos()
+		})
+		indexer.debug("Enum %v - %v", n.Id(), len(n.enum.values))
 	}
-	return s
 }
 
 // createFunctionInfo create function info
 func (indexer *Indexer) createFunctionInfo(pkg *PackageInfo, signature *types.Signature, data *types.Func) *FunctionInfo {
 	f := &FunctionInfo{
-		pkg:         pkg,
-		signature:   signature,
-		data:        data,
-		decl:        pkg.ast.functions[data.Name()],
-		annotations: map[string]*AnnotationInfo{},
+		pkg:       pkg,
+		signature: signature,
+		data:      data,
+		decl:      pkg.ast.functions[data.Name()],
 	}
 	pkg.functions = append(pkg.functions, f)
 	if f.decl == nil {
 		return f
 	}
 
-	anno := f.decl.Annotations(indexer.config.DefaultAnnoRegex)
-	if anno != nil {
-		f.annotations = anno
+	anno, err := f.decl.Annotations(indexer.annotationParser())
+	indexer.error(pkg.Id(), err)
+	f.annotations = anno
+	for _, name := range annotationNames(anno) {
+		indexer.cacheAF[name] = append(indexer.cacheAF[name], f)
 	}
+	indexer.indexAnnotations(f)
 	return f
 }
 
-// loadPackages load packages
-func (indexer *Indexer) loadPackages(pattern ...string) ([]*packages.Package, error) {
+// loadPackages load packages of the build configuration
+func (indexer *Indexer) loadPackages(build *BuildConfig, pattern ...string) ([]*packages.Package, error) {
 
 	indexer.mode = packages.NeedSyntax |
 		packages.NeedName |
@@ -398,104 +1569,296 @@ func (indexer *Indexer) loadPackages(pattern ...string) ([]*packages.Package, er
 		packages.NeedFiles
 
 	indexer.mode = indexer.mode | indexer.config.Mode
+	if indexer.config.MainModuleOnly || indexer.config.SkipGoPackages {
+		indexer.mode |= packages.NeedModule
+	}
 
-	cfg := &packages.Config{Mode: indexer.mode}
+	cfg := indexer.packagesConfig(build)
 	pkgs, err := packages.Load(cfg, pattern...)
 	if err != nil {
 		return nil, fmt.Errorf("loading packages for inspection: %v", err)
 	}
-	if packages.PrintErrors(pkgs) > 0 {
-		return nil, fmt.Errorf("loading packages with errors")
-	}
+	indexer.testVariants = testVariants(pkgs)
 	return pkgs, nil
 }
 
 // Load load packages by default pattern ./...
-func (indexer *Indexer) Load() error {
+func (indexer *Indexer) Load() ([]Diagnostic, error) {
 	return indexer.LoadPattern(indexer.config.DefaultPattern...)
 }
 
-// LoadPattern load packages by the pattern to the indexer
-func (indexer *Indexer) LoadPattern(pattern ...string) error {
-	// load packages
-	pkgs, err := indexer.loadPackages(pattern...)
+// LoadPattern load packages by the pattern to the indexer. Returns diagnostics of the packages and
+// annotations. Without the tolerant mode the package errors stop the loading and the annotation
+// errors are also returned as the error. In the tolerant mode the packages with errors are skipped
+// and the error is returned only if the packages could not be loaded. The diagnostics of the build
+// configurations are reported once with the name of the first build configuration.
+func (indexer *Indexer) LoadPattern(pattern ...string) ([]Diagnostic, error) {
+	filter, err := createPackageFilter(indexer.config)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	indexer.filter = filter
+
+	indexer.errors = nil
+	indexer.diagnostics = nil
+	indexer.reported = map[string]int{}
+	indexer.implements = nil
+	indexer.universes = map[*types.Package]map[string]*types.Package{}
+
+	// load packages of all build configurations
+	builds := indexer.config.BuildConfigs
+	if len(builds) == 0 {
+		builds = []*BuildConfig{nil}
+	}
+	for i, build := range builds {
+		indexer.build, indexer.buildIndex = build, i
+		pkgs, err := indexer.loadPackages(build, pattern...)
+		if err == nil {
+			err = indexer.processPackages(pkgs)
+		}
+		if err != nil {
+			indexer.build = nil
+			return indexer.diagnostics, err
+		}
 	}
+	indexer.build = nil
+
+	// stable order of the annotation index regardless of the package load order
+	sortIndex(indexer.cacheA)
+	sortIndex(indexer.cacheAI)
+	sortIndex(indexer.cacheAM)
+	sortIndex(indexer.cacheAN)
+	sortIndex(indexer.cacheAC)
+	sortIndex(indexer.cacheAV)
+	sortIndex(indexer.cacheAP)
+	sortIndex(indexer.cacheAF)
+	sortIndex(indexer.cacheAE)
+
+	if indexer.config.Tolerant {
+		return indexer.diagnostics, nil
+	}
+	return indexer.diagnostics, errors.Join(indexer.errors...)
+}
+
+// processPackages indexes the packages and the imports by the import depth. The diagnostics of the visited
+// packages are collected before the indexing, without the tolerant mode the package errors stop the loading.
+// The package with errors or the package filtered by the package filters is not indexed but the imports
+// are indexed. The imports of the already indexed package are not visited.
+func (indexer *Indexer) processPackages(pkgs []*packages.Package) error {
+	selected := []*packages.Package{}
+	diagnostics := []Diagnostic{}
+	indexer.walkPackages(pkgs, func(pkg *packages.Package) bool {
+		// check package filters
+		if !indexer.filterPackage(pkg) {
+			return true
+		}
+		// check if package already process, the test variant is merged with the regular package of the previous load
+		if _, e := indexer.cacheP[pkg.PkgPath]; e && indexer.buildIndex == 0 && packageKind(pkg) != PackageTestVariant {
+			indexer.debug("Skip read pkg: %v", pkg.PkgPath)
+			return false
+		}
+		diagnostics = append(diagnostics, indexer.buildDiagnostics(packageDiagnostics(pkg))...)
+		// skip package with errors in the tolerant mode
+		if len(pkg.Errors) > 0 || pkg.Types == nil {
+			indexer.debug("Skip pkg with errors: %v", pkg.PkgPath)
+			return true
+		}
+		selected = append(selected, pkg)
+		return true
+	})
 
-	// loop over all packages
-	for _, pkg := range pkgs {
+	indexer.diagnostics = append(indexer.diagnostics, diagnostics...)
+	if len(diagnostics) > 0 && !indexer.config.Tolerant {
+		return &DiagnosticsError{Diagnostics: diagnostics}
+	}
+	for _, pkg := range selected {
 		indexer.processPackage(pkg)
 	}
-
 	return nil
 }
 
+// walkPackages visits the packages and the imports by the import depth. The imports of the package
+// skipped by skipPackage or the package for which the visit function returns false are not visited.
+func (indexer *Indexer) walkPackages(pkgs []*packages.Package, visit func(pkg *packages.Package) bool) {
+	depth := 1
+	visited := map[string]struct{}{}
+	for len(pkgs) > 0 {
+		imports := []*packages.Package{}
+		for _, pkg := range pkgs {
+			if _, e := visited[pkg.ID]; e {
+				continue
+			}
+			visited[pkg.ID] = struct{}{}
+			if indexer.skipPackage(pkg) || !visit(pkg) {
+				continue
+			}
+			for _, path := range slices.Sorted(maps.Keys(pkg.Imports)) {
+				imports = append(imports, pkg.Imports[path])
+			}
+		}
+		if indexer.config.MaxImportDepth > 0 && depth >= indexer.config.MaxImportDepth {
+			break
+		}
+		pkgs = imports
+		depth++
+	}
+}
+
+// processPackage indexes the package. The already indexed package of the next build configuration
+// is merged with the package of the previous build configurations.
 func (indexer *Indexer) processPackage(pkg *packages.Package) {
-	// check golang package
-	if indexer.config.SkipGoPackages && IsGoPackage(pkg.PkgPath) {
-		indexer.debug("Skip go pkg: %v", pkg.PkgPath)
-		return
+	pkgInfo, e := indexer.cacheP[pkg.PkgPath]
+	if e {
+		// merge package of the next build configuration
+		indexer.mergePackage(pkgInfo, pkg)
+	} else {
+		// create module info
+		indexer.createModuleInfo(pkg)
+
+		// create package info
+		pkgInfo = indexer.createPackageInfo(pkg)
+		indexer.processObjects(pkgInfo, pkg, nil)
 	}
-	// check if package already process
-	if _, e := indexer.cacheP[pkg.PkgPath]; e {
-		indexer.debug("Skip read pkg: %v", pkg.PkgPath)
-		return
+	if indexer.build != nil {
+		indexer.recordBuild(pkgInfo, pkg)
 	}
+}
 
-	// create module info
-	indexer.createModuleInfo(pkg)
-
-	// create package info
-	pkgInfo := indexer.createPackageInfo(pkg)
+// processObjects creates infos of the package level objects except the existing objects
+func (indexer *Indexer) processObjects(pkgInfo *PackageInfo, pkg *packages.Package, existing map[string]struct{}) {
+	consts := []*types.Const{}
 
 	// loop over all types
 	for _, name := range pkg.Types.Scope().Names() {
+		if _, e := existing[name]; e {
+			continue
+		}
 		obj := pkg.Types.Scope().Lookup(name)
 
-		switch objT := obj.Type().(type) {
-		case *types.Named:
-			switch undT := objT.Underlying().(type) {
+		switch objT := obj.(type) {
+		case *types.TypeName:
+			named, ok := objT.Type().(*types.Named)
+			if !ok || objT.IsAlias() {
+				indexer.debug("load pattern not supported type name %v - %T", objT, objT.Type())
+				continue
+			}
+			switch undT := named.Underlying().(type) {
 			case *types.Struct:
-				indexer.createStructInfo(pkgInfo, objT, undT)
+				indexer.createStructInfo(pkgInfo, named, undT)
 			case *types.Interface:
-				indexer.createInterfaceInfo(pkgInfo, objT, undT)
+				indexer.createInterfaceInfo(pkgInfo, named, undT)
 			default:
-				indexer.debug("load pattern not supported named type %v - %T", undT, undT)
-			}
-		case *types.Signature:
-			switch st := obj.(type) {
-			case *types.Func:
-				indexer.createFunctionInfo(pkgInfo, objT, st)
+				indexer.createNamedTypeInfo(pkgInfo, named)
 			}
+		case *types.Func:
+			indexer.createFunctionInfo(pkgInfo, objT.Type().(*types.Signature), objT)
+		case *types.Const:
+			indexer.createConstInfo(pkgInfo, objT)
+			consts = append(consts, objT)
+		case *types.Var:
+			indexer.createVarInfo(pkgInfo, objT)
 		default:
 			indexer.debug("load pattern not supported object type %v - %T", objT, objT)
 		}
 	}
 
-	// check all imports
-	if len(pkg.Imports) > 0 {
-		for _, v := range pkg.Imports {
-			indexer.processPackage(v)
-		}
-	}
+	// create enums from the typed constants
+	indexer.createEnumInfos(pkgInfo, consts)
 }
 
 func (indexer *Indexer) MainModule() *ModuleInfo {
 	return indexer.mainModule
 }
 
-// FindStructsByAnnotation find all structs by annotation
+// FindStructsByAnnotation find all structs by annotation.
+// Returns copy of the index list sorted by id.
 func (indexer *Indexer) FindStructsByAnnotation(name string) []*StructInfo {
-	return indexer.cacheA[name]
+	return copyOf(indexer.cacheA[name])
+}
+
+// FindPackagesByAnnotation find all packages by annotation.
+// Returns copy of the index list sorted by id.
+func (indexer *Indexer) FindPackagesByAnnotation(name string) []*PackageInfo {
+	return copyOf(indexer.cacheAP[name])
+}
+
+// FindMethodsByAnnotation find all methods by annotation.
+// Returns copy of the index list sorted by id.
+func (indexer *Indexer) FindMethodsByAnnotation(name string) []*MethodInfo {
+	return copyOf(indexer.cacheAM[name])
+}
+
+// FindInterfacesByAnnotation find all interfaces by annotation.
+// Returns copy of the index list sorted by id.
+func (indexer *Indexer) FindInterfacesByAnnotation(name string) []*InterfaceInfo {
+	return copyOf(indexer.cacheAI[name])
+}
+
+// FindFunctionsByAnnotation find all functions by annotation.
+// Returns copy of the index list sorted by id.
+func (indexer *Indexer) FindFunctionsByAnnotation(name string) []*FunctionInfo {
+	return copyOf(indexer.cacheAF[name])
+}
+
+// FindByAnnotation find all elements of any kind by annotation.
+// Returns copy of the index list sorted by id.
+func (indexer *Indexer) FindByAnnotation(name string) []Element {
+	return copyOf(indexer.cacheAE[name])
+}
+
+// FindByAnnotationPrefix find all elements of any kind by the hierarchical annotation name prefix.
+// The prefix `kubebuilder:validation` matches `kubebuilder:validation:Minimum`.
+func (indexer *Indexer) FindByAnnotationPrefix(prefix string) []Element {
+	result := []Element{}
+	exists := map[Element]struct{}{}
+	for name, items := range indexer.cacheAE {
+		if name != prefix && !strings.HasPrefix(name, prefix+":") {
+			continue
+		}
+		for _, e := range items {
+			if _, ok := exists[e]; !ok {
+				exists[e] = struct{}{}
+				result = append(result, e)
+			}
+		}
+	}
+	SortById(result)
+	return result
+}
+
+// FindInterfacesImplementedBy find all interfaces implemented by the struct or named type
+func (indexer *Indexer) FindInterfacesImplementedBy(name string) []*ImplementationInfo {
+	if s := indexer.cacheS[name]; s != nil {
+		return indexer.implementsIndex().implementedBy(s)
+	}
+	if n := indexer.cacheN[name]; n != nil {
+		return indexer.implementsIndex().implementedBy(n)
+	}
+	indexer.debug("Type not found %v", name)
+	return nil
+}
+
+// FindImplementations find all structs and named types which implement the interface ordered by id
+func (indexer *Indexer) FindImplementations(name string) []Implementer {
+	interfaceInfo := indexer.cacheI[name]
+	if interfaceInfo == nil {
+		indexer.debug("Interface not found %v", name)
+		return nil
+	}
+	return indexer.implementsIndex().implementations(interfaceInfo)
 }
 
-// FindInterfacesByAnnotation find all interfaces by annotation
-func (indexer *Indexer) FindInterfacesByAnnotation(name string) []*StructInfo {
-	return indexer.cacheA[name]
+// FindSubInterfaces find all interfaces which embed the interface directly or transitively ordered by id
+func (indexer *Indexer) FindSubInterfaces(name string) []*InterfaceInfo {
+	interfaceInfo := indexer.cacheI[name]
+	if interfaceInfo == nil {
+		indexer.debug("Interface not found %v", name)
+		return nil
+	}
+	return indexer.implementsIndex().subInterfaces(interfaceInfo)
 }
 
-// FindInterfaceImplementation find all interface implementations
+// FindInterfaceImplementations find all structs which implement the interface ordered by id
 func (indexer *Indexer) FindInterfaceImplementations(name string) []*StructInfo {
 	interfaceInfo := indexer.cacheI[name]
 	if interfaceInfo == nil {
@@ -504,19 +1867,60 @@ func (indexer *Indexer) FindInterfaceImplementations(name string) []*StructInfo
 	}
 
 	result := []*StructInfo{}
-	for _, s := range indexer.cacheS {
-		if s.Implements(interfaceInfo) {
+	for _, t := range indexer.implementsIndex().implementations(interfaceInfo) {
+		if s, ok := t.(*StructInfo); ok {
 			result = append(result, s)
 		}
 	}
 	return result
 }
 
+// implementsIndex returns the implementation index, the index is created on the first use
+func (indexer *Indexer) implementsIndex() *implementsIndex {
+	if indexer.implements != nil {
+		return indexer.implements
+	}
+
+	implementers := []Implementer{}
+	for _, s := range indexer.cacheS {
+		implementers = append(implementers, s)
+	}
+	for _, n := range indexer.cacheN {
+		implementers = append(implementers, n)
+	}
+	sort.Slice(implementers, func(i, j int) bool {
+		return implementers[i].Id() < implementers[j].Id()
+	})
+
+	interfaces := []*InterfaceInfo{}
+	for _, i := range indexer.cacheI {
+		interfaces = append(interfaces, i)
+	}
+	sort.Slice(interfaces, func(i, j int) bool {
+		return interfaces[i].Id() < interfaces[j].Id()
+	})
+
+	indexer.implements = newImplementsIndex(implementers, interfaces)
+	return indexer.implements
+}
+
 // Packages return map of all modules
 func (indexer *Indexer) Modules() map[string]*ModuleInfo {
 	return indexer.cacheM
 }
 
+// SortedModules return list of all modules ordered by module path
+func (indexer *Indexer) SortedModules() []*ModuleInfo {
+	result := make([]*ModuleInfo, 0, len(indexer.cacheM))
+	for _, m := range indexer.cacheM {
+		result = append(result, m)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].data.Path < result[j].data.Path
+	})
+	return result
+}
+
 // Package returns module by module path or nil
 func (indexer *Indexer) Module(path string) *ModuleInfo {
 	return indexer.cacheM[path]
@@ -527,6 +1931,11 @@ func (indexer *Indexer) Packages() map[string]*PackageInfo {
 	return indexer.cacheP
 }
 
+// SortedPackages return list of all packages ordered by id
+func (indexer *Indexer) SortedPackages() []*PackageInfo {
+	return sortedById(indexer.cacheP)
+}
+
 // Package returns package by package path or nil
 func (indexer *Indexer) Package(pkgPath string) *PackageInfo {
 	return indexer.cacheP[pkgPath]
@@ -537,16 +1946,104 @@ func (indexer *Indexer) Interfaces() map[string]*InterfaceInfo {
 	return indexer.cacheI
 }
 
+// SortedInterfaces return list of all interfaces ordered by id
+func (indexer *Indexer) SortedInterfaces() []*InterfaceInfo {
+	return sortedById(indexer.cacheI)
+}
+
 // Interface returns by name or nil
 func (indexer *Indexer) Interface(name string) *InterfaceInfo {
 	return indexer.cacheI[name]
 }
 
+// FindNamedTypesByAnnotation find all named types by annotation.
+// Returns copy of the index list sorted by id.
+func (indexer *Indexer) FindNamedTypesByAnnotation(name string) []*NamedTypeInfo {
+	return copyOf(indexer.cacheAN[name])
+}
+
+// FindConstsByAnnotation find all constants by annotation.
+// Returns copy of the index list sorted by id.
+func (indexer *Indexer) FindConstsByAnnotation(name string) []*ConstInfo {
+	return copyOf(indexer.cacheAC[name])
+}
+
+// FindVarsByAnnotation find all variables by annotation.
+// Returns copy of the index list sorted by id.
+func (indexer *Indexer) FindVarsByAnnotation(name string) []*VarInfo {
+	return copyOf(indexer.cacheAV[name])
+}
+
+// Consts return map of all package level constants
+func (indexer *Indexer) Consts() map[string]*ConstInfo {
+	return indexer.cacheC
+}
+
+// SortedConsts return list of all package level constants ordered by id
+func (indexer *Indexer) SortedConsts() []*ConstInfo {
+	return sortedById(indexer.cacheC)
+}
+
+// Const returns constant by id or nil
+func (indexer *Indexer) Const(id string) *ConstInfo {
+	return indexer.cacheC[id]
+}
+
+// Vars return map of all package level variables
+func (indexer *Indexer) Vars() map[string]*VarInfo {
+	return indexer.cacheV
+}
+
+// SortedVars return list of all package level variables ordered by id
+func (indexer *Indexer) SortedVars() []*VarInfo {
+	return sortedById(indexer.cacheV)
+}
+
+// Var returns variable by id or nil
+func (indexer *Indexer) Var(id string) *VarInfo {
+	return indexer.cacheV[id]
+}
+
+// Enums return map of all enums
+func (indexer *Indexer) Enums() map[string]*EnumInfo {
+	return indexer.cacheE
+}
+
+// SortedEnums return list of all enums ordered by id
+func (indexer *Indexer) SortedEnums() []*EnumInfo {
+	return sortedById(indexer.cacheE)
+}
+
+// Enum returns enum by id of the named type or nil
+func (indexer *Indexer) Enum(id string) *EnumInfo {
+	return indexer.cacheE[id]
+}
+
+// NamedTypes return map of all named types which are not struct or interface
+func (indexer *Indexer) NamedTypes() map[string]*NamedTypeInfo {
+	return indexer.cacheN
+}
+
+// SortedNamedTypes return list of all named types ordered by id
+func (indexer *Indexer) SortedNamedTypes() []*NamedTypeInfo {
+	return sortedById(indexer.cacheN)
+}
+
+// NamedType returns named type by id or nil
+func (indexer *Indexer) NamedType(id string) *NamedTypeInfo {
+	return indexer.cacheN[id]
+}
+
 // Structs return map of all structs
 func (indexer *Indexer) Structs() map[string]*StructInfo {
 	return indexer.cacheS
 }
 
+// SortedStructs return list of all structs ordered by id
+func (indexer *Indexer) SortedStructs() []*StructInfo {
+	return sortedById(indexer.cacheS)
+}
+
 // Struct returns struct by name or nil
 func (indexer *Indexer) Struct(name string) *StructInfo {
 	return indexer.cacheS[name]
@@ -575,9 +2072,22 @@ func CreateIndexer(config *IndexerConfig) *Indexer {
 		cacheP:   map[string]*PackageInfo{},
 		cacheI:   map[string]*InterfaceInfo{},
 		cacheS:   map[string]*StructInfo{},
+		cacheN:   map[string]*NamedTypeInfo{},
+		cacheE:   map[string]*EnumInfo{},
+		cacheC:   map[string]*ConstInfo{},
+		cacheV:   map[string]*VarInfo{},
 		cacheA:   map[string][]*StructInfo{},
 		cacheAI:  map[string][]*InterfaceInfo{},
+		cacheAM:  map[string][]*MethodInfo{},
+		cacheAN:  map[string][]*NamedTypeInfo{},
+		cacheAC:  map[string][]*ConstInfo{},
+		cacheAP:  map[string][]*PackageInfo{},
+		cacheAF:  map[string][]*FunctionInfo{},
+		cacheAE:  map[string][]Element{},
+		cacheAV:  map[string][]*VarInfo{},
 		cacheM:   map[string]*ModuleInfo{},
+		fset:     token.NewFileSet(),
+		builds:   map[string]*buildInfo{},
 	}
 
 }
@@ -587,57 +2097,43 @@ func id(pkg *PackageInfo, named *types.Named) string {
 	return pkg.data.PkgPath + "." + named.Obj().Name()
 }
 
-// createAnnotations this method creates list of annotations info from the comments
-func createAnnotations(comment *ast.CommentGroup, r *regexp.Regexp) map[string]*AnnotationInfo {
-	// ignore annotation for empty comment
-	if comment == nil {
-		return nil
-	}
-	if len(comment.List) == 0 {
-		return nil
-	}
-
-	result := map[string]*AnnotationInfo{}
-
-	for _, c := range comment.List {
-		sm := r.FindString(c.Text)
-		if len(sm) == 0 {
-			continue
-		}
-
-		// create annotation
-		anno := &AnnotationInfo{
-			Name:   strings.TrimSuffix(strings.TrimPrefix(sm, "//"), " "),
-			Params: map[string]string{},
-		}
-
-		// check annotation parameters only if found annotation does not equals the comment
-		if sm != c.Text {
-
-			// remove annotation and first space
-			tmp := strings.Split(strings.TrimPrefix(c.Text, sm+" "), " ")
-			if len(tmp) > 0 {
-				for _, t := range tmp {
-					param := strings.Split(t, "=")
-					anno.Params[param[0]] = param[1]
-				}
-			}
-		}
+// namedId generate ID for the named type from the type package
+func namedId(named *types.Named) string {
+	return named.Obj().Pkg().Path() + "." + named.Obj().Name()
+}
 
-		result[anno.Name] = anno
-	}
-	return result
+// objectId generate ID for the package level object (constant, variable)
+func objectId(pkg *PackageInfo, obj types.Object) string {
+	return pkg.data.PkgPath + "." + obj.Name()
 }
 
 // AstFuncDecl ast type declaration
 type AstTypeDecl struct {
-	decl *ast.GenDecl
-	ast  *ast.TypeSpec
-}
-
-// Annotations returns list of annotations
-func (a *AstTypeDecl) Annotations(r *regexp.Regexp) map[string]*AnnotationInfo {
-	return createAnnotations(a.decl.Doc, r)
+	fset    *token.FileSet
+	decl    *ast.GenDecl
+	group   *astGroupDecl
+	ast     *ast.TypeSpec
+	markers *ast.CommentGroup
+}
+
+// Annotations returns list of annotations. See declAnnotations for the rules of the grouped declarations.
+// In the marker mode the annotations of the marker comment group above the doc comment are the first.
+func (a *AstTypeDecl) Annotations(p AnnotationParser) ([]*AnnotationInfo, error) {
+	result, err := createAnnotations(a.markers, p, a.fset)
+	tmp, err2 := declAnnotations(a.group, a.ast, a.ast.Doc, a.ast.Comment, p)
+	return append(result, tmp...), errors.Join(err, err2)
+}
+
+// Doc returns doc comment of the type specification. The declaration doc
+// comment is used only for not grouped declarations.
+func (a *AstTypeDecl) Doc() *ast.CommentGroup {
+	if a.ast.Doc != nil {
+		return a.ast.Doc
+	}
+	if !a.decl.Lparen.IsValid() {
+		return a.decl.Doc
+	}
+	return nil
 }
 
 // GenDecl struct type of the type
@@ -645,6 +2141,11 @@ func (a *AstTypeDecl) GenDecl() *ast.GenDecl {
 	return a.decl
 }
 
+// TypeSpec type specification of the type
+func (a *AstTypeDecl) TypeSpec() *ast.TypeSpec {
+	return a.ast
+}
+
 // StructType struct type of the type
 func (a *AstTypeDecl) StructType() *ast.StructType {
 	return a.ast.Type.(*ast.StructType)
@@ -657,12 +2158,13 @@ func (a *AstTypeDecl) InterfaceType() *ast.InterfaceType {
 
 // AstFuncDecl ast function declaration
 type AstFuncDecl struct {
+	fset *token.FileSet
 	decl *ast.FuncDecl
 }
 
 // Annotations returns list of annotations
-func (a *AstFuncDecl) Annotations(r *regexp.Regexp) map[string]*AnnotationInfo {
-	return createAnnotations(a.decl.Doc, r)
+func (a *AstFuncDecl) Annotations(p AnnotationParser) ([]*AnnotationInfo, error) {
+	return createAnnotations(a.decl.Doc, p, a.fset)
 }
 
 // FuncType struct type of the type
@@ -670,38 +2172,213 @@ func (a *AstFuncDecl) FuncType() *ast.FuncType {
 	return a.decl.Type
 }
 
+// AstValueDecl ast constant or variable declaration
+type AstValueDecl struct {
+	fset  *token.FileSet
+	decl  *ast.GenDecl
+	group *astGroupDecl
+	ast   *ast.ValueSpec
+}
+
+// GenDecl declaration of the value
+func (a *AstValueDecl) GenDecl() *ast.GenDecl {
+	return a.decl
+}
+
+// ValueSpec value specification
+func (a *AstValueDecl) ValueSpec() *ast.ValueSpec {
+	return a.ast
+}
+
+// Annotations returns list of annotations. See declAnnotations for the rules of the grouped declarations.
+func (a *AstValueDecl) Annotations(p AnnotationParser) ([]*AnnotationInfo, error) {
+	return declAnnotations(a.group, a.ast, a.ast.Doc, a.ast.Comment, p)
+}
+
+// Doc returns doc comment of the value specification. The line comment is used
+// if there is no doc comment and the declaration doc only for not grouped declarations.
+func (a *AstValueDecl) Doc() *ast.CommentGroup {
+	if a.ast.Doc != nil {
+		return a.ast.Doc
+	}
+	if a.ast.Comment != nil {
+		return a.ast.Comment
+	}
+	if !a.decl.Lparen.IsValid() {
+		return a.decl.Doc
+	}
+	return nil
+}
+
+// AstField ast struct field or interface method declaration
+type AstField struct {
+	fset *token.FileSet
+	ast  *ast.Field
+}
+
+// Field field declaration
+func (a *AstField) Field() *ast.Field {
+	return a.ast
+}
+
+// Annotations returns list of annotations from the doc and line comment
+func (a *AstField) Annotations(p AnnotationParser) ([]*AnnotationInfo, error) {
+	doc, err := createAnnotations(a.ast.Doc, p, a.fset)
+	line, err2 := createAnnotations(a.ast.Comment, p, a.fset)
+	return append(doc, line...), errors.Join(err, err2)
+}
+
 // AstInfo syntax info
 type AstInfo struct {
 	functions map[string]*AstFuncDecl
+	methods   map[string]*AstFuncDecl
 	types     map[string]*AstTypeDecl
+	values    map[string]*AstValueDecl
+	fields    map[token.Position]*AstField
 }
 
 // processAstInfo find all types and functions in the AST
 func (indexer *Indexer) processAstInfo(pkg *packages.Package) *AstInfo {
 	result := &AstInfo{
 		functions: map[string]*AstFuncDecl{},
+		methods:   map[string]*AstFuncDecl{},
 		types:     map[string]*AstTypeDecl{},
+		values:    map[string]*AstValueDecl{},
+		fields:    map[token.Position]*AstField{},
 	}
 	indexer.debug("Ast %v", pkg.Syntax)
 	for _, syntax := range pkg.Syntax {
+		prev := syntax.Name.End()
 		for _, decl := range syntax.Decls {
 			switch dt := decl.(type) {
 			case *ast.GenDecl:
+				group := newAstGroupDecl(pkg.Fset, dt, indexer.annotationParser())
+				specPrev := dt.Lparen
 				for _, spec := range dt.Specs {
-					if ts, ok := spec.(*ast.TypeSpec); ok {
-						result.types[ts.Name.Name] = &AstTypeDecl{decl: dt, ast: ts}
+					switch st := spec.(type) {
+					case *ast.TypeSpec:
+						var markers *ast.CommentGroup
+						switch {
+						case !indexer.config.MarkerMode:
+						case dt.Lparen.IsValid():
+							markers = markerComments(pkg.Fset, syntax, specPrev, st.Doc, st.Pos())
+						default:
+							markers = markerComments(pkg.Fset, syntax, prev, dt.Doc, dt.Pos())
+						}
+						specPrev = st.End()
+						result.types[st.Name.Name] = &AstTypeDecl{fset: pkg.Fset, decl: dt, group: group, ast: st, markers: markers}
+					case *ast.ValueSpec:
+						v := &AstValueDecl{fset: pkg.Fset, decl: dt, group: group, ast: st}
+						for _, n := range st.Names {
+							result.values[n.Name] = v
+						}
 					}
 				}
 			case *ast.FuncDecl:
-				result.functions[dt.Name.Name] = &AstFuncDecl{decl: dt}
+				if dt.Recv == nil || len(dt.Recv.List) == 0 {
+					result.functions[dt.Name.Name] = &AstFuncDecl{fset: pkg.Fset, decl: dt}
+					continue
+				}
+				if recv := receiverName(dt.Recv.List[0].Type); len(recv) > 0 {
+					result.methods[methodKey(recv, dt.Name.Name)] = &AstFuncDecl{fset: pkg.Fset, decl: dt}
+				}
 			default:
 				panic(fmt.Errorf("not supported decl type %v - %T", dt, dt))
 			}
+			prev = decl.End()
 		}
+
+		// struct fields and interface methods
+		ast.Inspect(syntax, func(n ast.Node) bool {
+			var list *ast.FieldList
+			switch nt := n.(type) {
+			case *ast.StructType:
+				list = nt.Fields
+			case *ast.InterfaceType:
+				list = nt.Methods
+			default:
+				return true
+			}
+			for _, field := range list.List {
+				idents := field.Names
+				if len(idents) == 0 {
+					if ident := embeddedIdent(field.Type); ident != nil {
+						idents = []*ast.Ident{ident}
+					}
+				}
+				f := &AstField{fset: pkg.Fset, ast: field}
+				for _, ident := range idents {
+					if obj := pkg.TypesInfo.Defs[ident]; obj != nil {
+						result.fields[fieldKey(pkg.Fset, obj)] = f
+					}
+				}
+			}
+			return true
+		})
+	}
+	return result
+}
+
+// sortedFields returns the positions of the fields in the source order
+func sortedFields(fields map[token.Position]*AstField) []token.Position {
+	result := make([]token.Position, 0, len(fields))
+	for key := range fields {
+		result = append(result, key)
 	}
+	sort.Slice(result, func(i, j int) bool {
+		x, y := result[i], result[j]
+		if x.Filename != y.Filename {
+			return x.Filename < y.Filename
+		}
+		if x.Line != y.Line {
+			return x.Line < y.Line
+		}
+		return x.Column < y.Column
+	})
 	return result
 }
 
+// embeddedIdent returns the type name identifier of the embedded field
+func embeddedIdent(expr ast.Expr) *ast.Ident {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t
+	case *ast.SelectorExpr:
+		return t.Sel
+	case *ast.StarExpr:
+		return embeddedIdent(t.X)
+	case *ast.ParenExpr:
+		return embeddedIdent(t.X)
+	case *ast.IndexExpr:
+		return embeddedIdent(t.X)
+	case *ast.IndexListExpr:
+		return embeddedIdent(t.X)
+	}
+	return nil
+}
+
+// methodKey key of the method in the AST info
+func methodKey(recv, name string) string {
+	return recv + "." + name
+}
+
+// receiverName returns the type name of the method receiver expression
+func receiverName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return receiverName(t.X)
+	case *ast.ParenExpr:
+		return receiverName(t.X)
+	case *ast.IndexExpr:
+		return receiverName(t.X)
+	case *ast.IndexListExpr:
+		return receiverName(t.X)
+	}
+	return ""
+}
+
 type FieldStructInfo struct {
 	Info     *StructInfo
 	Parent   *FieldInfo
@@ -709,6 +2386,7 @@ type FieldStructInfo struct {
 	Struct   *types.Struct
 	Level    int
 	Metadata map[string]string
+	indexer  *Indexer
 }
 
 func (f *FieldStructInfo) Name() string {
@@ -731,11 +2409,25 @@ func (f *FieldStructInfo) Tag(index int) string {
 }
 
 func (f *FieldStructInfo) Field(index int) *FieldInfo {
-	return &FieldInfo{
+	field := &FieldInfo{
 		Index:    index,
 		Struct:   f,
 		Metadata: map[string]string{},
 	}
+	if f.indexer != nil {
+		v := f.Var(index).Origin()
+		field.ast = f.indexer.fieldAst(v)
+		field.annotations = f.indexer.fieldAnnotations(v)
+	}
+	return field
+}
+
+func (f *FieldStructInfo) FieldList() []*FieldInfo {
+	fields := make([]*FieldInfo, f.NumFields())
+	for i := range fields {
+		fields[i] = f.Field(i)
+	}
+	return fields
 }
 
 func (f *FieldStructInfo) Fields() map[string]*FieldInfo {
@@ -751,6 +2443,13 @@ type FieldInfo struct {
 	Struct   *FieldStructInfo
 	Index    int
 	Metadata map[string]string
+	ast      *AstField
+	annotated
+}
+
+// Ast declaration of the field or nil
+func (f *FieldInfo) Ast() *AstField {
+	return f.ast
 }
 
 func (f *FieldInfo) Var() *types.Var {
@@ -769,6 +2468,33 @@ func (f *FieldInfo) Name() string {
 	return f.Var().Name()
 }
 
+// Doc doc comment or line comment of the field without annotations
+func (f *FieldInfo) Doc() *DocInfo {
+	if f.ast == nil {
+		return &DocInfo{}
+	}
+	return createDocInfo(f.Struct.indexer.annotationParser(), f.ast.ast.Doc, f.ast.ast.Comment)
+}
+
+// Pos position of the field
+func (f *FieldInfo) Pos() token.Pos {
+	return f.Var().Pos()
+}
+
+// Position file position of the field. The dependencies are loaded with the file set
+// of the indexer, the position is valid for the fields of the not indexed packages too.
+func (f *FieldInfo) Position() token.Position {
+	if f.Struct.indexer == nil {
+		return token.Position{}
+	}
+	return f.Struct.indexer.fset.Position(f.Pos())
+}
+
+// File file name of the field
+func (f *FieldInfo) File() string {
+	return f.Position().Filename
+}
+
 const tag_empty = ""
 
 func (f *FieldInfo) TagValue(name string) (string, bool) {
@@ -787,6 +2513,7 @@ func (f *FieldInfo) FieldStructInfo(named *types.Named, struc *types.Struct) *Fi
 		Named:    named,
 		Struct:   struc,
 		Level:    f.Struct.Level + 1,
+		indexer:  f.Struct.indexer,
 	}
 }
 
@@ -801,7 +2528,7 @@ func walkStruct(struc *FieldStructInfo, walk FieldStructWalk) {
 
 		walk.FieldBefore(field)
 
-		switch n := field.Type().(type) {
+		switch n := types.Unalias(field.Type()).(type) {
 		case *types.Basic:
 			walk.Basic(field, n)
 		case *types.Slice:
@@ -867,6 +2594,13 @@ func walkStruct(struc *FieldStructInfo, walk FieldStructWalk) {
 			case *types.Interface:
 				walk.Interface(field, n, nn)
 			}
+		case *types.TypeParam:
+			tpw, ok := walk.(TypeParamWalk)
+			if ok && tpw.TypeParam(field, n, n.Constraint().Underlying().(*types.Interface)) {
+				if nn, st := coreStruct(n); st != nil {
+					walkStruct(field.FieldStructInfo(nn, st), walk)
+				}
+			}
 		}
 
 		walk.FieldAfter(field)
@@ -888,6 +2622,43 @@ type FieldStructWalk interface {
 	StructAfter(s *FieldStructInfo)
 }
 
+// TypeParamWalk optional walk of the fields with the type parameter type. The walk
+// continues with the struct of the constraint if the method returns true.
+type TypeParamWalk interface {
+	TypeParam(f *FieldInfo, t *types.TypeParam, constraint *types.Interface) bool
+}
+
+// annotationParser returns the annotation parser of the configuration
+func (indexer *Indexer) annotationParser() AnnotationParser {
+	if indexer.parser == nil {
+		indexer.parser = indexer.config.AnnotationParser
+		if indexer.parser == nil {
+			indexer.parser = NewDefaultAnnotationParser(indexer.config.DefaultAnnoRegex)
+		}
+		if indexer.config.MarkerMode {
+			indexer.parser = MultiAnnotationParser(indexer.parser, NewMarkerAnnotationParser())
+		}
+	}
+	return indexer.parser
+}
+
+// indexAnnotations adds the element to the annotation index of all kinds
+func (indexer *Indexer) indexAnnotations(e Element) {
+	for _, name := range annotationNames(e.Annotations()) {
+		indexer.cacheAE[name] = append(indexer.cacheAE[name], e)
+	}
+}
+
+// error collects the error found during the processing of the package
+func (indexer *Indexer) error(pkgPath string, err error) {
+	if err == nil {
+		return
+	}
+	indexer.debug("Error %v", err)
+	indexer.errors = append(indexer.errors, err)
+	indexer.diagnostics = append(indexer.diagnostics, indexer.buildDiagnostics(errorDiagnostics(pkgPath, err))...)
+}
+
 func (indexer *Indexer) debug(msg string, a ...interface{}) {
 	if !indexer.config.Debug {
 		return
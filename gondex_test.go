@@ -2,15 +2,21 @@ package gondex
 
 import (
 	"fmt"
+	"go/constant"
+	"go/token"
 	"go/types"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+
+	"golang.org/x/tools/go/packages"
 )
 
 func TestTypes(t *testing.T) {
 	indexer := CreateDefaultIndexer()
 	indexer.config.Debug = true
-	if e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/types"); e != nil {
+	if _, e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/types"); e != nil {
 		panic(e)
 	}
 	s := indexer.Structs()
@@ -54,18 +60,35 @@ func TestTypes(t *testing.T) {
 
 func TestAnnotation(t *testing.T) {
 	indexer := CreateDefaultIndexer()
-	if e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test", "github.com/go-gluon/gondex/internal/test/project"); e != nil {
+	if _, e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test", "github.com/go-gluon/gondex/internal/test/project"); e != nil {
 		panic(e)
 	}
 	items := indexer.FindInterfacesByAnnotation("test:test")
-	if len(items) == 0 {
+	if len(items) != 1 || items[0].Name() != "TestI" {
 		panic(fmt.Errorf("No items found"))
 	}
+	functions := indexer.FindFunctionsByAnnotation("test:test")
+	if len(functions) != 1 || functions[0].Id() != "github.com/go-gluon/gondex/internal/test.NewUserTest" {
+		panic(fmt.Errorf("No functions found"))
+	}
+
+	kinds := map[string]string{}
+	for _, e := range indexer.FindByAnnotation("test:test") {
+		kinds[e.Name()] = fmt.Sprintf("%T", e)
+	}
+	expected := map[string]string{
+		"TestI":       "*gondex.InterfaceInfo",
+		"UserTest":    "*gondex.StructInfo",
+		"NewUserTest": "*gondex.FunctionInfo",
+	}
+	if !reflect.DeepEqual(kinds, expected) {
+		panic(fmt.Errorf("wrong elements by annotation %v", kinds))
+	}
 }
 
 func TestFieldStructWalk(t *testing.T) {
 	indexer := CreateDefaultIndexer()
-	if e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test", "github.com/go-gluon/gondex/internal/test/project"); e != nil {
+	if _, e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test", "github.com/go-gluon/gondex/internal/test/project"); e != nil {
 		panic(e)
 	}
 
@@ -121,6 +144,11 @@ func (e *ExampleFieldWalk) Struct(f *FieldInfo, n *types.Named, t *types.Struct)
 	return true
 }
 
+func (e *ExampleFieldWalk) TypeParam(f *FieldInfo, t *types.TypeParam, constraint *types.Interface) bool {
+	fmt.Printf("%v%v %v.%v %v %v\n", f.Struct.Level, e.space, f.Struct.Name(), f.Name(), t, constraint)
+	return true
+}
+
 func (e *ExampleFieldWalk) StructBefore(s *FieldStructInfo) bool {
 	e.space = e.space + "    "
 	return true
@@ -129,3 +157,538 @@ func (e *ExampleFieldWalk) StructBefore(s *FieldStructInfo) bool {
 func (e *ExampleFieldWalk) StructAfter(s *FieldStructInfo) {
 	e.space = strings.TrimSuffix(e.space, "    ")
 }
+
+func TestMethods(t *testing.T) {
+	indexer := CreateDefaultIndexer()
+	if _, e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/methods"); e != nil {
+		panic(e)
+	}
+
+	st_name := "github.com/go-gluon/gondex/internal/test/methods.Handler"
+	st := indexer.Struct(st_name)
+	if st == nil {
+		panic(fmt.Errorf("defined struct not found! %v", st_name))
+	}
+	if len(st.Methods()) != 2 {
+		panic(fmt.Errorf("struct %v methods not found %v", st_name, st.Methods()))
+	}
+	methods := st.Methods()
+	methods[0] = nil
+	if st.Methods()[0] == nil {
+		panic(fmt.Errorf("struct %v methods modified by the caller", st_name))
+	}
+
+	m := st.Method("Name")
+	if m == nil || !m.PointerReceiver() {
+		panic(fmt.Errorf("pointer receiver method Name not found %v", m))
+	}
+	if m.Annotation("test:route").Params["path"] != "/name" {
+		panic(fmt.Errorf("method Name annotation not found %v", m.Annotations()))
+	}
+	if m.Id() != st_name+".Name" {
+		panic(fmt.Errorf("wrong method id %v", m.Id()))
+	}
+	m = st.Method("Value")
+	if m == nil || m.PointerReceiver() {
+		panic(fmt.Errorf("value receiver method Value not found %v", m))
+	}
+
+	items := indexer.FindMethodsByAnnotation("test:route")
+	if len(items) != 2 {
+		panic(fmt.Errorf("methods by annotation not found %v", items))
+	}
+
+	fn := indexer.Package("github.com/go-gluon/gondex/internal/test/methods").functions
+	if len(fn) != 1 || fn[0].Annotation("test:func") == nil {
+		panic(fmt.Errorf("function annotation overwritten by method %v", fn))
+	}
+}
+
+func TestNamedTypes(t *testing.T) {
+	indexer := CreateDefaultIndexer()
+	if _, e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/named"); e != nil {
+		panic(e)
+	}
+
+	prefix := "github.com/go-gluon/gondex/internal/test/named."
+	kinds := map[string]TypeKind{
+		"Status":      KindBasic,
+		"HandlerFunc": KindFunc,
+		"IDs":         KindSlice,
+		"Lookup":      KindMap,
+	}
+	for name, kind := range kinds {
+		n := indexer.NamedType(prefix + name)
+		if n == nil {
+			panic(fmt.Errorf("named type not found! %v", name))
+		}
+		if n.Kind() != kind {
+			panic(fmt.Errorf("named type %v wrong kind %v", name, n.Kind()))
+		}
+	}
+	if indexer.NamedType(prefix+"Alias") != nil {
+		panic(fmt.Errorf("alias indexed as named type"))
+	}
+
+	h := indexer.NamedType(prefix + "HandlerFunc")
+	if h.Annotation("test:handler") == nil {
+		panic(fmt.Errorf("named type annotation not found %v", h.Annotations()))
+	}
+	if h.Method("ServeHTTP") == nil {
+		panic(fmt.Errorf("named type method not found %v", h.Methods()))
+	}
+	if len(indexer.FindNamedTypesByAnnotation("test:enum")) != 1 {
+		panic(fmt.Errorf("named type by annotation not found"))
+	}
+}
+
+func TestEnums(t *testing.T) {
+	indexer := CreateDefaultIndexer()
+	if _, e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/enums"); e != nil {
+		panic(e)
+	}
+
+	prefix := "github.com/go-gluon/gondex/internal/test/enums."
+	if len(indexer.Enums()) != 2 {
+		panic(fmt.Errorf("wrong number of enums %v", indexer.Enums()))
+	}
+	if indexer.Enum(prefix+"Other") != nil {
+		panic(fmt.Errorf("named type without constants indexed as enum"))
+	}
+
+	status := indexer.Enum(prefix + "Status")
+	if status == nil || status.Type().Enum() != status {
+		panic(fmt.Errorf("enum Status not found"))
+	}
+	names := []string{}
+	for _, v := range status.Values() {
+		names = append(names, v.Name()+"="+constant.StringVal(v.Value())+":"+strings.TrimSpace(v.Doc().Text()))
+	}
+	expected := "StatusActive=active:StatusActive active status,StatusInactive=inactive:inactive status,StatusDeleted=deleted:StatusDeleted deleted status"
+	if strings.Join(names, ",") != expected {
+		panic(fmt.Errorf("wrong enum values %v", names))
+	}
+
+	level := indexer.Enum(prefix + "Level")
+	if level == nil || len(level.Values()) != 4 {
+		panic(fmt.Errorf("enum Level not found"))
+	}
+	values := level.Values()
+	values[0] = nil
+	if level.Values()[0] == nil {
+		panic(fmt.Errorf("enum values modified by the caller"))
+	}
+	if v, _ := constant.Int64Val(level.Value("LevelHigh").Value()); v != 2 {
+		panic(fmt.Errorf("wrong enum value LevelHigh %v", v))
+	}
+	if level.Value("LevelUnknown").Doc().Text() != "LevelUnknown unknown level\n" {
+		panic(fmt.Errorf("wrong enum value doc %v", level.Value("LevelUnknown").Doc().Text()))
+	}
+}
+
+func TestValues(t *testing.T) {
+	indexer := CreateDefaultIndexer()
+	if _, e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/values"); e != nil {
+		panic(e)
+	}
+
+	prefix := "github.com/go-gluon/gondex/internal/test/values."
+	beans := indexer.FindVarsByAnnotation("test:bean")
+	if len(beans) != 2 {
+		panic(fmt.Errorf("wrong number of beans %v", beans))
+	}
+	for _, b := range beans {
+		if b.Annotation("test:bean").Params["name"] == "" {
+			panic(fmt.Errorf("bean annotation without name %v", b.Id()))
+		}
+	}
+
+	v := indexer.Var(prefix + "Timeout")
+	if v == nil || v.Annotation("test:config") == nil {
+		panic(fmt.Errorf("variable Timeout does not have declaration annotation"))
+	}
+	if v.Position().Line != 12 {
+		panic(fmt.Errorf("wrong position of the variable Timeout %v", v.Position()))
+	}
+	if v = indexer.Var(prefix + "noAnnotation"); v == nil || len(v.Annotations()) != 0 {
+		panic(fmt.Errorf("variable noAnnotation not found"))
+	}
+
+	c := indexer.Const(prefix + "Version")
+	if c == nil || constant.StringVal(c.Value()) != "1.0.0" {
+		panic(fmt.Errorf("constant Version not found"))
+	}
+	if len(indexer.FindConstsByAnnotation("test:version")) != 1 {
+		panic(fmt.Errorf("constant by annotation not found"))
+	}
+}
+
+type typeParamWalk struct {
+	ExampleFieldWalk
+	params []string
+	fields []string
+}
+
+func (e *typeParamWalk) Basic(f *FieldInfo, t *types.Basic) {
+	e.fields = append(e.fields, f.Struct.Name()+"."+f.Name())
+}
+
+func (e *typeParamWalk) TypeParam(f *FieldInfo, t *types.TypeParam, constraint *types.Interface) bool {
+	e.params = append(e.params, f.Name()+" "+t.String())
+	return true
+}
+
+func TestGenerics(t *testing.T) {
+	indexer := CreateDefaultIndexer()
+	if _, e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/generics"); e != nil {
+		panic(e)
+	}
+
+	prefix := "github.com/go-gluon/gondex/internal/test/generics."
+	box := indexer.Struct(prefix + "Box")
+	if box == nil || box.TypeParams().Len() != 1 {
+		panic(fmt.Errorf("generic struct Box not found"))
+	}
+	if box.Method("Get") == nil {
+		panic(fmt.Errorf("generic struct Box method not found"))
+	}
+
+	number := indexer.Interface(prefix + "Number")
+	if number == nil || !number.IsConstraint() {
+		panic(fmt.Errorf("constraint Number not found"))
+	}
+
+	getter := indexer.Interface(prefix + "Getter")
+	if getter == nil || getter.IsConstraint() || getter.TypeParams().Len() != 1 {
+		panic(fmt.Errorf("generic interface Getter not found"))
+	}
+	impl := indexer.FindInterfaceImplementations(getter.Id())
+	if len(impl) != 3 {
+		panic(fmt.Errorf("wrong generic interface implementations %v", impl))
+	}
+
+	sbox := indexer.Struct(prefix + "StringBox")
+	if !sbox.ImplementsInstance(getter, types.Typ[types.String]) {
+		panic(fmt.Errorf("StringBox does not implement Getter[string]"))
+	}
+	if sbox.ImplementsInstance(getter, types.Typ[types.Int]) {
+		panic(fmt.Errorf("StringBox implements Getter[int]"))
+	}
+
+	for _, f := range indexer.Package(strings.TrimSuffix(prefix, ".")).functions {
+		if f.Name() == "Sum" && f.TypeParams().Len() != 1 {
+			panic(fmt.Errorf("generic function Sum without type parameters"))
+		}
+	}
+
+	w := &typeParamWalk{}
+	indexer.Struct(prefix + "Wrapper").Fields(w)
+	if strings.Join(w.params, ",") != "Inner T" || strings.Join(w.fields, ",") != "Base.Name" {
+		panic(fmt.Errorf("wrong type parameter walk %v %v", w.params, w.fields))
+	}
+
+	// walk without the optional TypeParamWalk skips the type parameter fields
+	inner := &typeParamWalk{}
+	indexer.Struct(prefix + "Wrapper").Fields(struct{ FieldStructWalk }{inner})
+	if len(inner.params) != 0 || len(inner.fields) != 0 {
+		panic(fmt.Errorf("wrong walk without type parameters %v %v", inner.params, inner.fields))
+	}
+}
+
+type annotationWalk struct {
+	ExampleFieldWalk
+	fields []string
+}
+
+func (e *annotationWalk) FieldBefore(f *FieldInfo) bool {
+	for _, a := range f.Annotations() {
+		e.fields = append(e.fields, fmt.Sprintf("%v.%v %v %v", f.Struct.Name(), f.Name(), a.Name, a.Params))
+	}
+	return true
+}
+
+func TestFieldAnnotations(t *testing.T) {
+	indexer := CreateDefaultIndexer()
+	if _, e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/inject"); e != nil {
+		panic(e)
+	}
+
+	prefix := "github.com/go-gluon/gondex/internal/test/inject."
+	w := &annotationWalk{}
+	indexer.Struct(prefix + "Service").Fields(w)
+	expected := []string{
+		"Service.Base test:inject map[qualifier:base]",
+		"Base.Log test:inject map[qualifier:base]",
+		"Service.Repo test:inject map[qualifier:db]",
+		"Service.Name test:config map[key:name]",
+		"Inner.Other test:inject map[]",
+	}
+	if strings.Join(w.fields, "\n") != strings.Join(expected, "\n") {
+		panic(fmt.Errorf("wrong field annotations %v", w.fields))
+	}
+
+	repo := indexer.Interface(prefix + "Repository")
+	if len(repo.Methods()) != 3 {
+		panic(fmt.Errorf("wrong interface methods %v", repo.Methods()))
+	}
+	if repo.Method("Find").Annotation("test:query").Params["sql"] != "select name from users" {
+		panic(fmt.Errorf("interface method annotation not found"))
+	}
+	if repo.Method("Save").Annotation("test:tx") == nil || len(repo.Method("Delete").Annotations()) != 0 {
+		panic(fmt.Errorf("wrong interface method annotations"))
+	}
+}
+
+func TestPackageAnnotations(t *testing.T) {
+	indexer := CreateDefaultIndexer()
+	if _, e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/module", "github.com/go-gluon/gondex/internal/test/project"); e != nil {
+		panic(e)
+	}
+
+	items := indexer.FindPackagesByAnnotation("test:module")
+	if len(items) != 1 || items[0].Name() != "module" {
+		panic(fmt.Errorf("package by annotation not found %v", items))
+	}
+	if items[0].Annotation("test:module").Params["name"] != "billing" || items[0].Annotation("test:tag") == nil {
+		panic(fmt.Errorf("wrong package annotations %v", items[0].Annotations()))
+	}
+	if len(indexer.Package("github.com/go-gluon/gondex/internal/test/project").Annotations()) != 0 {
+		panic(fmt.Errorf("package without annotations"))
+	}
+
+	elements := indexer.FindByAnnotation("test:module")
+	if len(elements) != 1 || elements[0] != Element(items[0]) {
+		panic(fmt.Errorf("package not found by annotation %v", elements))
+	}
+	if doc := items[0].Doc().Text(); doc != "Package module test module package\n" {
+		panic(fmt.Errorf("wrong package doc %q", doc))
+	}
+	if p := items[0].Position(); filepath.Base(p.Filename) != "module.go" || p.Line != 4 {
+		panic(fmt.Errorf("wrong package position %v", p))
+	}
+}
+
+func TestPositions(t *testing.T) {
+	indexer := CreateDefaultIndexer()
+	if _, e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/inject"); e != nil {
+		panic(e)
+	}
+
+	prefix := "github.com/go-gluon/gondex/internal/test/inject."
+	position := func(p token.Position) string {
+		return fmt.Sprintf("%v:%v:%v", filepath.Base(p.Filename), p.Line, p.Column)
+	}
+
+	s := indexer.Struct(prefix + "Service")
+	repo := s.FieldStructInfo().Fields()["Repo"]
+	in := indexer.Interface(prefix + "Repository")
+	positions := map[string]string{
+		"struct":           position(s.Position()),
+		"field":            position(repo.Position()),
+		"field annotation": position(repo.Annotation("test:inject").Position()),
+		"interface":        position(in.Position()),
+		"method":           position(in.Method("Save").Position()),
+		"method line":      position(in.Method("Save").Annotation("test:tx").Position()),
+	}
+	expected := map[string]string{
+		"struct":           "inject.go:17:6",
+		"field":            "inject.go:21:2",
+		"field annotation": "inject.go:20:2",
+		"interface":        "inject.go:5:6",
+		"method":           "inject.go:8:2",
+		"method line":      "inject.go:8:20",
+	}
+	if !reflect.DeepEqual(positions, expected) {
+		panic(fmt.Errorf("wrong positions %v", positions))
+	}
+	if filepath.Base(s.File()) != "inject.go" || !s.Pos().IsValid() {
+		panic(fmt.Errorf("wrong struct file %v", s.File()))
+	}
+
+	data := s.FieldStructInfo().Fields()["Data"]
+	if !data.Pos().IsValid() || data.Position().Line != 23 {
+		panic(fmt.Errorf("wrong field position %v", data.Position()))
+	}
+
+	// field of the struct from the package which is not indexed
+	config := CreateDefaultConfig()
+	config.MaxImportDepth = 1
+	indexer = CreateIndexer(config)
+	if _, e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/inject"); e != nil {
+		panic(e)
+	}
+	data = indexer.Struct(prefix + "Service").FieldStructInfo().Fields()["Data"]
+	named := data.Type().(*types.Named)
+	if indexer.Package(named.Obj().Pkg().Path()) != nil {
+		panic(fmt.Errorf("package %v is indexed", named.Obj().Pkg().Path()))
+	}
+	name := data.FieldStructInfo(named, named.Underlying().(*types.Struct)).Field(0)
+	if p := name.Position(); filepath.Base(p.Filename) != "project.go" || p.Line != 4 {
+		panic(fmt.Errorf("wrong not indexed field position %v", p))
+	}
+}
+
+func TestDocs(t *testing.T) {
+	indexer := CreateDefaultIndexer()
+	if _, e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/docs"); e != nil {
+		panic(e)
+	}
+
+	prefix := "github.com/go-gluon/gondex/internal/test/docs."
+	user := indexer.Struct(prefix + "User")
+	if names := annotationNames(user.Annotations()); !reflect.DeepEqual(names, []string{"test:entity"}) {
+		panic(fmt.Errorf("go directive must not be annotation %v", names))
+	}
+	fields := user.FieldStructInfo().Fields()
+	store := indexer.Interface(prefix + "Store")
+	docs := map[string]string{
+		"struct":           user.Doc().Text(),
+		"field":            fields["Name"].Doc().Text(),
+		"field line":       fields["Email"].Doc().Text(),
+		"method":           user.Method("Get").Doc().Text(),
+		"grouped":          indexer.Struct(prefix + "Role").Doc().Text(),
+		"grouped no doc":   indexer.Struct(prefix + "Group").Doc().Text(),
+		"interface":        store.Doc().Text(),
+		"interface method": store.Method("Save").Doc().Text(),
+		"function":         indexer.Package(strings.TrimSuffix(prefix, ".")).functions[0].Doc().Text(),
+		"const":            indexer.Const(prefix + "Admin").Doc().Text(),
+	}
+	expected := map[string]string{
+		"struct":           "User represents the user.\n\nThe user has a name and an email.\n",
+		"field":            "Name of the user\n",
+		"field line":       "Email of the user\n",
+		"method":           "Get returns the user\n",
+		"grouped":          "Role of the user\n",
+		"grouped no doc":   "",
+		"interface":        "Store stores users\n",
+		"interface method": "Save saves the user\n",
+		"function":         "Create creates new user\n",
+		"const":            "Admin admin role name\n",
+	}
+	if !reflect.DeepEqual(docs, expected) {
+		panic(fmt.Errorf("wrong docs %q", docs))
+	}
+
+	paragraphs := user.Doc().Paragraphs()
+	if len(paragraphs) != 2 || paragraphs[1] != "The user has a name and an email." {
+		panic(fmt.Errorf("wrong doc paragraphs %q", paragraphs))
+	}
+	if !indexer.Struct(prefix + "Group").Doc().Empty() {
+		panic(fmt.Errorf("grouped struct without doc has doc"))
+	}
+}
+
+func TestIsGoPackage(t *testing.T) {
+	tests := map[string]bool{
+		"fmt":                                 true,
+		"net/http":                            true,
+		"unsafe":                              true,
+		"golang.org/x/tools/go/packages":      true,
+		"github.com/go-gluon/gondex":          false,
+		"github.com/go-gluon/gondex/internal": false,
+		"example.com/fmt":                     false,
+		"app/service":                         false,
+	}
+	for pkgPath, expected := range tests {
+		if IsGoPackage(pkgPath) != expected {
+			panic(fmt.Errorf("package %v expected go package %v", pkgPath, expected))
+		}
+	}
+
+	// module without the dot in the path
+	if isGoPackage(&packages.Package{PkgPath: "app/service", Module: &packages.Module{Path: "app"}}) || !isGoPackage(&packages.Package{PkgPath: "net/http"}) {
+		panic(fmt.Errorf("wrong go package of the module"))
+	}
+
+	indexer := CreateDefaultIndexer()
+	if _, e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/types"); e != nil {
+		panic(e)
+	}
+	for id := range indexer.Packages() {
+		if IsGoPackage(id) {
+			panic(fmt.Errorf("go package %v indexed", id))
+		}
+	}
+	if indexer.mode&packages.NeedModule == 0 {
+		panic(fmt.Errorf("packages loaded without the module for the go packages check"))
+	}
+}
+
+func TestTestPackages(t *testing.T) {
+	path := "github.com/go-gluon/gondex/internal/test/tests"
+
+	// test files are not loaded by default
+	indexer := CreateDefaultIndexer()
+	if _, e := indexer.LoadPattern(path); e != nil {
+		panic(e)
+	}
+	if len(indexer.FindInterfacesByAnnotation("test:mock")) != 0 || len(indexer.Packages()) != 1 {
+		panic(fmt.Errorf("test files loaded without the tests option %v", indexer.Packages()))
+	}
+
+	config := CreateDefaultConfig()
+	config.Tests = true
+	indexer = CreateIndexer(config)
+	if _, e := indexer.LoadPattern(path); e != nil {
+		panic(e)
+	}
+	if ids := ids(indexer.FindInterfacesByAnnotation("test:mock")); !reflect.DeepEqual(ids, []string{path + ".Repository", path + "_test.ExternalRepository"}) {
+		panic(fmt.Errorf("wrong test interfaces %v", ids))
+	}
+
+	pkg := indexer.Package(path)
+	if pkg.Kind() != PackageRegular || len(pkg.interfaces) != 2 || len(pkg.functions) != 1 || !IsTestFile(pkg.functions[0]) {
+		panic(fmt.Errorf("wrong regular package %v %v %v", pkg.Kind(), pkg.interfaces, pkg.functions))
+	}
+	if IsTestFile(indexer.Struct(path + ".Service")) {
+		panic(fmt.Errorf("regular struct in the test file"))
+	}
+	if ext := indexer.Package(path + "_test"); ext == nil || ext.Kind() != PackageExternalTest {
+		panic(fmt.Errorf("external test package not found"))
+	}
+	if indexer.Package(path+".test") != nil || len(indexer.Packages()) != 2 {
+		panic(fmt.Errorf("test main package indexed %v", indexer.Packages()))
+	}
+
+	// the test variant replaces the regular package, the test implementation has the same types
+	if pkg.Data().ID != path+" ["+path+".test]" {
+		panic(fmt.Errorf("package not indexed from the test variant %v", pkg.Data().ID))
+	}
+	if result := ids(indexer.FindImplementations(path + ".Store")); !reflect.DeepEqual(result, []string{path + ".mockStore"}) {
+		panic(fmt.Errorf("wrong test implementations %v", result))
+	}
+	store := indexer.Struct(path + ".mockStore")
+	if !types.Identical(store.Methods()[0].Signature().Results().At(0).Type(), indexer.Interface(path+".Store").Method("Get").Signature().Results().At(0).Type()) {
+		panic(fmt.Errorf("test implementation from the different type universe"))
+	}
+	if indexer.Package(pkg.Id()) != pkg {
+		panic(fmt.Errorf("package not found by the id %v", pkg.Id()))
+	}
+
+	// the importing package refers to the fields of the regular package
+	for _, tests := range []bool{false, true} {
+		config := CreateDefaultConfig()
+		config.Tests = tests
+		indexer = CreateIndexer(config)
+		if _, e := indexer.LoadPattern(path, path+"/client"); e != nil {
+			panic(e)
+		}
+		service := indexer.Struct(path + "/client.Client").FieldStructInfo().Fields()["Service"]
+		named := service.Type().(*types.Named)
+		name := service.FieldStructInfo(named, named.Underlying().(*types.Struct)).Fields()["Name"]
+		if name.Annotation("test:name") == nil || name.Ast() == nil {
+			panic(fmt.Errorf("tests %v: field annotations of the imported package not found %v", tests, name.Annotations()))
+		}
+	}
+}
+
+func TestUnifyArity(t *testing.T) {
+	tp := types.NewTypeParam(types.NewTypeName(token.NoPos, nil, "T", nil), types.NewInterfaceType(nil, nil))
+	one := types.NewTuple(types.NewVar(token.NoPos, nil, "a", tp))
+	two := types.NewTuple(types.NewVar(token.NoPos, nil, "a", types.Typ[types.Int]), types.NewVar(token.NoPos, nil, "b", types.Typ[types.Int]))
+	x := types.NewSignatureType(nil, nil, nil, one, nil, false)
+	y := types.NewSignatureType(nil, nil, nil, two, nil, false)
+	if unify(x, y, map[*types.TypeParam]types.Type{tp: nil}) {
+		panic(fmt.Errorf("signatures with different arity unified"))
+	}
+}
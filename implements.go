@@ -0,0 +1,241 @@
+package gondex
+
+import (
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// Implementer represents indexed named type which could implement interfaces (struct or named type)
+type Implementer interface {
+	Element
+	Named() *types.Named
+	Implements(interfaceInfo *InterfaceInfo) bool
+	ImplementsValue(interfaceInfo *InterfaceInfo) bool
+	ImplementedInterfaces() []*ImplementationInfo
+}
+
+var (
+	_ Implementer = (*StructInfo)(nil)
+	_ Implementer = (*NamedTypeInfo)(nil)
+)
+
+// implements returns true if the pointer or value type of the named type implements the interface
+func implements(named *types.Named, interfaceInfo *InterfaceInfo) bool {
+	if interfaceInfo == nil {
+		return false
+	}
+	t := typeOf(named)
+	if implementsInterface(types.NewPointer(t), interfaceInfo) {
+		return true
+	}
+	return implementsInterface(t, interfaceInfo)
+}
+
+// implementsValue returns true if the value type of the named type implements the interface
+func implementsValue(named *types.Named, interfaceInfo *InterfaceInfo) bool {
+	if interfaceInfo == nil {
+		return false
+	}
+	return implementsInterface(typeOf(named), interfaceInfo)
+}
+
+// methodSetGroup types with the same method set fingerprint
+type methodSetGroup struct {
+	fingerprint string
+	names       map[string]struct{}
+	types       []Implementer
+}
+
+// contains returns true if the group method set contains all methods
+func (g *methodSetGroup) contains(names []string) bool {
+	for _, name := range names {
+		if _, e := g.names[name]; !e {
+			return false
+		}
+	}
+	return true
+}
+
+// implementsIndex index of the type method sets for the implementation search.
+// Types are grouped by the method set fingerprint (sorted method names of the pointer
+// method set) and the groups are indexed by the method name.
+// The interfaces are indexed by the first method name for the reverse lookup
+// and by the embedded interfaces for the sub interface lookup.
+type implementsIndex struct {
+	groups     []*methodSetGroup
+	byMethod   map[string][]*methodSetGroup
+	results    map[string][]Implementer
+	interfaces map[string][]*InterfaceInfo
+	reverse    map[string][]*ImplementationInfo
+	extendedBy map[string][]*InterfaceInfo
+}
+
+// newImplementsIndex creates the implementation index for the types and interfaces
+func newImplementsIndex(types []Implementer, interfaces []*InterfaceInfo) *implementsIndex {
+	index := &implementsIndex{
+		groups:     []*methodSetGroup{},
+		byMethod:   map[string][]*methodSetGroup{},
+		results:    map[string][]Implementer{},
+		interfaces: map[string][]*InterfaceInfo{},
+		reverse:    map[string][]*ImplementationInfo{},
+		extendedBy: map[string][]*InterfaceInfo{},
+	}
+
+	for _, i := range interfaces {
+		key := ""
+		if names := interfaceMethodNames(i); len(names) > 0 {
+			key = names[0]
+		}
+		index.interfaces[key] = append(index.interfaces[key], i)
+		for _, e := range i.Extends() {
+			index.extendedBy[e.Id()] = append(index.extendedBy[e.Id()], i)
+		}
+	}
+
+	fingerprints := map[string]*methodSetGroup{}
+	for _, t := range types {
+		names := pointerMethodSetNames(t.Named())
+		fingerprint := strings.Join(names, ",")
+
+		group := fingerprints[fingerprint]
+		if group == nil {
+			group = &methodSetGroup{fingerprint: fingerprint, names: map[string]struct{}{}}
+			for _, name := range names {
+				group.names[name] = struct{}{}
+				index.byMethod[name] = append(index.byMethod[name], group)
+			}
+			fingerprints[fingerprint] = group
+			index.groups = append(index.groups, group)
+		}
+		group.types = append(group.types, t)
+	}
+	return index
+}
+
+// candidates returns groups which method set contains all methods
+func (index *implementsIndex) candidates(names []string) []*methodSetGroup {
+	if len(names) == 0 {
+		return index.groups
+	}
+
+	// start with the method with the smallest number of groups
+	groups := index.byMethod[names[0]]
+	for _, name := range names[1:] {
+		if tmp := index.byMethod[name]; len(tmp) < len(groups) {
+			groups = tmp
+		}
+	}
+
+	result := []*methodSetGroup{}
+	for _, g := range groups {
+		if g.contains(names) {
+			result = append(result, g)
+		}
+	}
+	return result
+}
+
+// implementations returns all types which implement the interface ordered by id.
+// Returns copy of the cached result.
+func (index *implementsIndex) implementations(interfaceInfo *InterfaceInfo) []Implementer {
+	if result, e := index.results[interfaceInfo.Id()]; e {
+		return append([]Implementer{}, result...)
+	}
+
+	result := []Implementer{}
+	for _, g := range index.candidates(interfaceMethodNames(interfaceInfo)) {
+		for _, t := range g.types {
+			if t.Implements(interfaceInfo) {
+				result = append(result, t)
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id() < result[j].Id()
+	})
+	index.results[interfaceInfo.Id()] = result
+	return append([]Implementer{}, result...)
+}
+
+// implementedBy returns all interfaces implemented by the type ordered by interface id.
+// Returns copy of the cached result.
+func (index *implementsIndex) implementedBy(t Implementer) []*ImplementationInfo {
+	if result, e := index.reverse[t.Id()]; e {
+		return append([]*ImplementationInfo{}, result...)
+	}
+
+	// interfaces without methods and interfaces indexed by the type methods
+	candidates := append([]*InterfaceInfo{}, index.interfaces[""]...)
+	names := pointerMethodSetNames(t.Named())
+	for _, name := range names {
+		candidates = append(candidates, index.interfaces[name]...)
+	}
+
+	group := &methodSetGroup{names: map[string]struct{}{}}
+	for _, name := range names {
+		group.names[name] = struct{}{}
+	}
+
+	result := []*ImplementationInfo{}
+	for _, i := range candidates {
+		if !group.contains(interfaceMethodNames(i)) {
+			continue
+		}
+		if t.ImplementsValue(i) {
+			result = append(result, &ImplementationInfo{Interface: i, Pointer: false})
+		} else if t.Implements(i) {
+			result = append(result, &ImplementationInfo{Interface: i, Pointer: true})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Interface.Id() < result[j].Interface.Id()
+	})
+	index.reverse[t.Id()] = result
+	return append([]*ImplementationInfo{}, result...)
+}
+
+// subInterfaces returns all interfaces which extend the interface directly or
+// by the embedded interfaces ordered by id
+func (index *implementsIndex) subInterfaces(interfaceInfo *InterfaceInfo) []*InterfaceInfo {
+	result := []*InterfaceInfo{}
+	seen := map[string]struct{}{interfaceInfo.Id(): {}}
+	queue := []*InterfaceInfo{interfaceInfo}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, i := range index.extendedBy[current.Id()] {
+			if _, e := seen[i.Id()]; e {
+				continue
+			}
+			seen[i.Id()] = struct{}{}
+			result = append(result, i)
+			queue = append(queue, i)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id() < result[j].Id()
+	})
+	return result
+}
+
+// interfaceMethodNames returns sorted method names of the interface
+func interfaceMethodNames(interfaceInfo *InterfaceInfo) []string {
+	names := make([]string, interfaceInfo.data.NumMethods())
+	for i := range names {
+		names[i] = interfaceInfo.data.Method(i).Name()
+	}
+	sort.Strings(names)
+	return names
+}
+
+// pointerMethodSetNames returns sorted method names of the pointer type method set
+func pointerMethodSetNames(named *types.Named) []string {
+	ms := types.NewMethodSet(types.NewPointer(typeOf(named)))
+	names := make([]string, ms.Len())
+	for i := 0; i < ms.Len(); i++ {
+		names[i] = ms.At(i).Obj().Name()
+	}
+	sort.Strings(names)
+	return names
+}
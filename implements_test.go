@@ -0,0 +1,120 @@
+package gondex
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestImplementsIndex(t *testing.T) {
+	indexer := CreateDefaultIndexer()
+	if _, e := indexer.LoadPattern(
+		"github.com/go-gluon/gondex/internal/test/types",
+		"github.com/go-gluon/gondex/internal/test/generics",
+		"github.com/go-gluon/gondex/internal/test/inject",
+		"github.com/go-gluon/gondex/internal/test",
+	); e != nil {
+		panic(e)
+	}
+
+	for id, in := range indexer.Interfaces() {
+		expected := []string{}
+		for _, s := range indexer.Structs() {
+			if s.Implements(in) {
+				expected = append(expected, s.Id())
+			}
+		}
+		sort.Strings(expected)
+
+		result := indexer.FindInterfaceImplementations(id)
+		if fmt.Sprint(ids(result)) != fmt.Sprint(expected) {
+			panic(fmt.Errorf("interface %v wrong implementations %v expected %v", id, ids(result), expected))
+		}
+
+		// the caller could modify the result without changing the cached result
+		all := indexer.FindImplementations(id)
+		if len(all) > 0 {
+			expected := ids(all)
+			all[0] = nil
+			if again := indexer.FindImplementations(id); fmt.Sprint(ids(again)) != fmt.Sprint(expected) {
+				panic(fmt.Errorf("interface %v cached implementations modified %v", id, ids(again)))
+			}
+		}
+	}
+}
+
+func ids[T interface{ Id() string }](items []T) []string {
+	result := []string{}
+	for _, item := range items {
+		result = append(result, item.Id())
+	}
+	return result
+}
+
+func TestImplementedInterfaces(t *testing.T) {
+	indexer := CreateDefaultIndexer()
+	if _, e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/types"); e != nil {
+		panic(e)
+	}
+
+	prefix := "github.com/go-gluon/gondex/internal/test/types."
+	result := []string{}
+	for _, i := range indexer.Struct(prefix + "Value").ImplementedInterfaces() {
+		result = append(result, fmt.Sprintf("%v:%v", i.Interface.Name(), i.Pointer))
+	}
+	if fmt.Sprint(result) != "[Any:false Setter:true Valuer:false]" {
+		panic(fmt.Errorf("wrong implemented interfaces %v", result))
+	}
+
+	// the caller could modify the result without changing the cached result
+	items := indexer.Struct(prefix + "Value").ImplementedInterfaces()
+	items[0] = nil
+	if again := indexer.Struct(prefix + "Value").ImplementedInterfaces(); again[0] == nil {
+		panic(fmt.Errorf("cached implemented interfaces modified"))
+	}
+
+	items = indexer.FindInterfacesImplementedBy(prefix + "Struct2")
+	if len(items) != 1 || items[0].Interface.Name() != "Any" {
+		panic(fmt.Errorf("wrong implemented interfaces of Struct2 %v", items))
+	}
+	if indexer.FindInterfacesImplementedBy(prefix+"Missing") != nil {
+		panic(fmt.Errorf("implemented interfaces of missing struct"))
+	}
+}
+
+func TestNamedTypeImplementations(t *testing.T) {
+	indexer := CreateDefaultIndexer()
+	if _, e := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/named"); e != nil {
+		panic(e)
+	}
+
+	prefix := "github.com/go-gluon/gondex/internal/test/named."
+	result := []string{}
+	for _, i := range indexer.FindImplementations(prefix + "Handler") {
+		result = append(result, i.Name())
+	}
+	if fmt.Sprint(result) != "[HandlerFunc]" {
+		panic(fmt.Errorf("wrong named type implementations %v", result))
+	}
+	if len(indexer.FindInterfaceImplementations(prefix+"Handler")) != 0 {
+		panic(fmt.Errorf("named type found as struct implementation"))
+	}
+
+	items := indexer.FindInterfacesImplementedBy(prefix + "IDs")
+	if len(items) != 1 || items[0].Interface.Name() != "Lener" || items[0].Pointer {
+		panic(fmt.Errorf("wrong interfaces implemented by named type %v", items))
+	}
+
+	rw := indexer.Interface(prefix + "ReadWriteHandler")
+	if len(rw.Extends()) != 1 || rw.Extends()[0].Name() != "ReadHandler" {
+		panic(fmt.Errorf("wrong extended interfaces %v", rw.Extends()))
+	}
+
+	result = []string{}
+	for _, i := range indexer.FindSubInterfaces(prefix + "Handler") {
+		result = append(result, i.Name())
+	}
+	if fmt.Sprint(result) != "[ReadHandler ReadWriteHandler]" {
+		panic(fmt.Errorf("wrong sub interfaces %v", result))
+	}
+}
@@ -0,0 +1,15 @@
+package annotations
+
+//test:route path="/hello world" method=GET method=POST secure
+type Route struct {
+}
+
+//test:invalid name="unterminated
+type Invalid struct {
+}
+
+//test:route path=/a
+//test:other
+//test:route path=/b
+type Multi struct {
+}
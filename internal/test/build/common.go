@@ -0,0 +1,27 @@
+package build
+
+import "io"
+
+// Common struct in all build configurations
+type Common struct {
+	// Label of the build configuration
+	//
+	//test:label
+	Label string
+}
+
+// Name method in all build configurations
+func (c *Common) Name() string {
+	return "common"
+}
+
+// Reader interface in all build configurations
+type Reader interface {
+	Read(r io.Reader) error
+}
+
+// Source generic interface in all build configurations
+type Source[T any] interface {
+	Reader
+	Next() T
+}
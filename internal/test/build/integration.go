@@ -0,0 +1,7 @@
+//go:build integration
+
+package build
+
+// Integration struct only for the integration build tag
+type Integration struct {
+}
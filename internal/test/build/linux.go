@@ -0,0 +1,14 @@
+//go:build linux
+
+package build
+
+// Linux struct only for linux
+//
+//test:linux
+type Linux struct {
+}
+
+// Platform method only for linux
+func (c *Common) Platform() string {
+	return "linux"
+}
@@ -0,0 +1,22 @@
+//go:build windows
+
+package build
+
+import "io"
+
+// Windows struct only for windows
+//
+//test:windows
+type Windows struct {
+	Common Common
+}
+
+// Read method only for windows
+func (w *Windows) Read(r io.Reader) error {
+	return nil
+}
+
+// Next method only for windows
+func (w *Windows) Next() Common {
+	return w.Common
+}
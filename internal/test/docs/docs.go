@@ -0,0 +1,48 @@
+package docs
+
+// User represents the user.
+//
+// The user has a name and an email.
+//
+//test:entity table=users
+//go:generate sh -c "echo hi"
+type User struct {
+	// Name of the user
+	//test:column
+	Name  string
+	Email string // Email of the user
+}
+
+// Get returns the user
+//
+//test:route path=/user
+func (u *User) Get() *User {
+	return u
+}
+
+// group doc
+type (
+	// Role of the user
+	Role struct {
+	}
+
+	Group struct {
+	}
+)
+
+// Store stores users
+type Store interface {
+	// Save saves the user
+	//test:tx
+	Save(u *User)
+}
+
+// Create creates new user
+func Create() *User {
+	return &User{}
+}
+
+const (
+	// Admin admin role name
+	Admin = "admin"
+)
@@ -0,0 +1,26 @@
+package enums
+
+type Status string
+
+const (
+	// StatusActive active status
+	StatusActive   Status = "active"
+	StatusInactive Status = "inactive" // inactive status
+	// StatusDeleted deleted status
+	StatusDeleted Status = "deleted"
+)
+
+type Level int
+
+const (
+	LevelLow Level = iota
+	LevelMedium
+	LevelHigh
+)
+
+// LevelUnknown unknown level
+const LevelUnknown Level = -1
+
+const untyped = "untyped"
+
+type Other int
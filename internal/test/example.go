@@ -20,6 +20,7 @@ type Special2 struct {
 	Options map[string]Special `test:"options"`
 }
 
+//test:test
 type TestI interface {
 	test() string
 }
@@ -56,3 +57,8 @@ type UserTest struct {
 		Number int    `test:"number"`
 	} `test:"map-struct"`
 }
+
+//test:test
+func NewUserTest() *UserTest {
+	return &UserTest{}
+}
@@ -0,0 +1,8 @@
+package a
+
+import "github.com/go-gluon/gondex/internal/test/filter/b"
+
+// A imports the package b
+type A struct {
+	B b.B
+}
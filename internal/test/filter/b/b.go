@@ -0,0 +1,8 @@
+package b
+
+import "github.com/go-gluon/gondex/internal/test/filter/c"
+
+// B imports the package c
+type B struct {
+	C c.C
+}
@@ -0,0 +1,8 @@
+package c
+
+import "fmt"
+
+// C imports the package fmt
+type C struct {
+	Value fmt.Stringer
+}
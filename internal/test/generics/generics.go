@@ -0,0 +1,50 @@
+package generics
+
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+type Getter[T any] interface {
+	Get() T
+}
+
+type Box[T any] struct {
+	Value T
+	Items []T
+}
+
+func (b *Box[T]) Get() T {
+	return b.Value
+}
+
+type StringBox struct {
+	value string
+}
+
+func (b StringBox) Get() string {
+	return b.value
+}
+
+type IntBox struct {
+	value int
+}
+
+func (b IntBox) Get() int {
+	return b.value
+}
+
+type Base struct {
+	Name string
+}
+
+type Wrapper[T interface{ Base }] struct {
+	Inner T
+}
+
+func Sum[T Number](values ...T) T {
+	var result T
+	for _, v := range values {
+		result += v
+	}
+	return result
+}
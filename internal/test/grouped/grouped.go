@@ -0,0 +1,20 @@
+package grouped
+
+//test:group name=models
+type (
+	//test:entity table=a
+	A struct {
+	}
+
+	//test:group name=b
+	B struct {
+	}
+
+	C interface {
+		M()
+	}
+)
+
+//test:single
+type D struct {
+}
@@ -0,0 +1,28 @@
+package inject
+
+import "github.com/go-gluon/gondex/internal/test/project"
+
+type Repository interface {
+	//test:query sql="select name from users"
+	Find() string
+	Save(name string) //test:tx
+	Delete()
+}
+
+type Base struct {
+	//test:inject qualifier=base
+	Log string
+}
+
+type Service struct {
+	//test:inject qualifier=base
+	Base
+	//test:inject qualifier=db
+	Repo  Repository
+	Name  string //test:config key=name
+	Data  project.ProjectTest
+	Inner struct {
+		//test:inject
+		Other Repository
+	}
+}
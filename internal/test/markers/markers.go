@@ -0,0 +1,54 @@
+// +groupName=example.io
+package markers
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch
+
+// Memcached is the Schema for the memcacheds API
+// +kubebuilder:resource:path=memcacheds,scope=Namespaced
+type Memcached struct {
+	// Size of the deployment
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=5
+	Size int32
+
+	// Mode of the deployment
+	// +kubebuilder:validation:Enum=a;b;c
+	// +kubebuilder:default:="a"
+	Mode string
+
+	//gluon:Inject name=memcached
+	Client string
+}
+
+// +kubebuilder:object:root=true
+// MemcachedList list of the memcached
+type MemcachedList struct {
+	Items []Memcached
+}
+
+// +kubebuilder:object:root=true
+
+// Config configuration
+type Config struct {
+	Name string
+}
+
+type (
+	// +kubebuilder:object:root=true
+	// +kubebuilder:subresource:status
+
+	// Webhook grouped type with markers
+	Webhook struct {
+	}
+
+	// +kubebuilder:object:generate=false
+
+	Hook struct {
+	} // +kubebuilder:skip
+
+	Plain struct {
+	}
+)
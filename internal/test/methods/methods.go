@@ -0,0 +1,20 @@
+package methods
+
+type Handler struct {
+	name string
+}
+
+//test:route path=/name
+func (h *Handler) Name() string {
+	return h.name
+}
+
+//test:route path=/value
+func (h Handler) Value() string {
+	return h.name
+}
+
+//test:func
+func Name() string {
+	return "name"
+}
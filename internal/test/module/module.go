@@ -0,0 +1,7 @@
+// Package module test module package
+//
+//test:module name=billing
+package module
+
+type Invoice struct {
+}
@@ -0,0 +1,2 @@
+//test:tag value=b
+package module
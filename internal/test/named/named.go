@@ -0,0 +1,41 @@
+package named
+
+import "net/http"
+
+//test:enum
+type Status string
+
+//test:handler
+type HandlerFunc func(w http.ResponseWriter, r *http.Request)
+
+func (f HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f(w, r)
+}
+
+type IDs []string
+
+type Lookup map[string]IDs
+
+type Alias = Status
+
+func (ids IDs) Len() int {
+	return len(ids)
+}
+
+type Lener interface {
+	Len() int
+}
+
+type Handler interface {
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type ReadHandler interface {
+	Handler
+	Read()
+}
+
+type ReadWriteHandler interface {
+	ReadHandler
+	Write()
+}
@@ -0,0 +1,11 @@
+package parsers
+
+// Service service with the different annotation syntax
+// @Route(path="/x", method={GET, POST})
+// +kubebuilder:validation:Minimum=1
+//
+//go:generate stringer -type=Service "quoted arg"
+type Service struct {
+	// @Inject
+	Name string
+}
@@ -0,0 +1,13 @@
+package app
+
+// App struct with the invalid annotation
+//
+//test:app name="unterminated
+type App struct {
+}
+
+// Service struct
+//
+//test:service
+type Service struct {
+}
@@ -0,0 +1,9 @@
+package broken
+
+import "github.com/go-gluon/gondex/internal/test/testdata/good"
+
+// Broken struct with the type error
+type Broken struct {
+	Good good.Good
+	Name Missing
+}
@@ -0,0 +1,17 @@
+package fields
+
+// Fields struct with the invalid field annotations
+type Fields struct {
+	//test:field name="a
+	A string
+	//test:field name="b
+	B string
+	//test:field name="c
+	C string
+	//test:field name="d
+	D string
+	//test:field name="e
+	E string
+	//test:field name="f
+	F, G string
+}
@@ -0,0 +1,8 @@
+package good
+
+// Good package without errors
+//
+//test:good
+type Good struct {
+	Name string
+}
@@ -0,0 +1,8 @@
+package uses
+
+import "github.com/go-gluon/gondex/internal/test/testdata/broken"
+
+// Uses struct with the field from the package with errors
+type Uses struct {
+	Broken broken.Broken
+}
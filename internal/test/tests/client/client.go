@@ -0,0 +1,8 @@
+package client
+
+import "github.com/go-gluon/gondex/internal/test/tests"
+
+// Client struct with the service of the package with the test files
+type Client struct {
+	Service tests.Service
+}
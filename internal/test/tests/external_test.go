@@ -0,0 +1,10 @@
+package tests_test
+
+import "github.com/go-gluon/gondex/internal/test/tests"
+
+// ExternalRepository interface declared in the external test package
+//
+//test:mock
+type ExternalRepository interface {
+	Save(s *tests.Service) error
+}
@@ -0,0 +1,14 @@
+package tests
+
+// Service regular struct
+type Service struct {
+	// Name of the service
+	//
+	//test:name
+	Name string
+}
+
+// Store interface implemented in the test
+type Store interface {
+	Get() *Service
+}
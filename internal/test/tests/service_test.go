@@ -0,0 +1,22 @@
+package tests
+
+// Repository interface declared only in the test
+//
+//test:mock
+type Repository interface {
+	Find(id string) string
+}
+
+// newTestService test helper
+func newTestService() *Service {
+	return &Service{}
+}
+
+// mockStore test implementation of the Store
+type mockStore struct {
+}
+
+// Get returns the test service
+func (m *mockStore) Get() *Service {
+	return newTestService()
+}
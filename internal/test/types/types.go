@@ -35,3 +35,25 @@ func TestMethod() {
 	}
 	fmt.Printf("%v\n", test)
 }
+
+type Valuer interface {
+	Value() string
+}
+
+type Any interface{}
+
+type Setter interface {
+	SetName(name string)
+}
+
+type Value struct {
+	name string
+}
+
+func (v Value) Value() string {
+	return v.name
+}
+
+func (v *Value) SetName(name string) {
+	v.name = name
+}
@@ -0,0 +1,18 @@
+package values
+
+import "net/http"
+
+//test:bean name=client
+var DefaultClient = &http.Client{}
+
+//test:config
+var (
+	//test:bean name=server
+	DefaultServer = &http.Server{}
+	Timeout       = 10
+)
+
+//test:version
+const Version = "1.0.0"
+
+var noAnnotation int
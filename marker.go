@@ -0,0 +1,244 @@
+package gondex
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var markerAnnotationRegex = regexp.MustCompile(`^//\s*\+([0-9A-Za-z_\.\-/:]+)`)
+
+// kubebuilderMarkers markers with the named arguments `+kubebuilder:rbac:groups=apps,verbs=get;list`
+var kubebuilderMarkers = []string{
+	"kubebuilder:rbac",
+	"kubebuilder:printcolumn",
+	"kubebuilder:resource",
+	"kubebuilder:selectablefield",
+	"kubebuilder:subresource:scale",
+	"kubebuilder:validation:XValidation",
+	"kubebuilder:webhook",
+	"kubebuilder:webhookconfiguration",
+}
+
+// markerAnnotationParser parser for the kubebuilder/controller-gen markers
+type markerAnnotationParser struct {
+	named map[string]struct{}
+}
+
+// NewMarkerAnnotationParser creates parser for the kubebuilder/controller-gen markers
+// `+kubebuilder:validation:Minimum=1`, `+kubebuilder:validation:Enum=a;b;c` or
+// `+kubebuilder:printcolumn:name="Age",type=date`.
+//
+// The name of the marker is hierarchical with the `:` separator. The single argument
+// of the marker is stored as the parameter `value`. The named arguments are recognized
+// for the known kubebuilder markers, the named markers of the parameter and for the markers
+// with more arguments `+name:first=1,second=2`, the name of the first argument is the last
+// part of the name. The list arguments `a;b;c` or `{a,b,c}` are stored as the list of values.
+func NewMarkerAnnotationParser(named ...string) AnnotationParser {
+	p := &markerAnnotationParser{named: map[string]struct{}{}}
+	for _, name := range kubebuilderMarkers {
+		p.named[name] = struct{}{}
+	}
+	for _, name := range named {
+		p.named[name] = struct{}{}
+	}
+	return p
+}
+
+// Parse parses the annotation
+func (p *markerAnnotationParser) Parse(text string) (*AnnotationInfo, error) {
+	m := markerAnnotationRegex.FindStringSubmatch(text)
+	if m == nil {
+		return nil, nil
+	}
+	name := strings.TrimSuffix(m[1], ":")
+	rest := text[len(m[0]):]
+	switch {
+	case len(rest) == 0:
+		return newAnnotationInfo(name), nil
+	case unicode.IsSpace(rune(rest[0])):
+		if len(strings.TrimSpace(rest)) > 0 {
+			return newAnnotationInfo(name), &AnnotationSyntaxError{Annotation: name, Offset: len(m[0]), Msg: "unexpected text after the marker"}
+		}
+		return newAnnotationInfo(name), nil
+	case rest[0] != '=':
+		return nil, nil
+	}
+
+	offset := len(m[0]) + 1
+	args, err := splitMarkerArgs(text[offset:])
+	if err != nil {
+		return newAnnotationInfo(name), &AnnotationSyntaxError{Annotation: name, Offset: offset, Msg: err.Error()}
+	}
+
+	// named arguments `+name:first=1,second=2`
+	key := "value"
+	if i := strings.LastIndex(name, ":"); i > 0 && (p.isNamed(name[:i]) || namedMarkerArgs(args)) {
+		name, key = name[:i], name[i+1:]
+	}
+
+	anno := newAnnotationInfo(name)
+	for i, arg := range args {
+		if i > 0 {
+			kv := strings.SplitN(arg, "=", 2)
+			if len(kv) != 2 || !isMarkerIdent(strings.TrimSpace(kv[0])) {
+				return anno, &AnnotationSyntaxError{Annotation: name, Offset: offset, Msg: fmt.Sprintf("invalid argument %q", arg)}
+			}
+			key, arg = strings.TrimSpace(kv[0]), kv[1]
+		}
+		if err := addMarkerArg(anno, key, arg); err != nil {
+			return anno, &AnnotationSyntaxError{Annotation: name, Offset: offset, Msg: err.Error()}
+		}
+	}
+	return anno, nil
+}
+
+// isNamed returns true if the marker has named arguments
+func (p *markerAnnotationParser) isNamed(name string) bool {
+	_, e := p.named[name]
+	return e
+}
+
+// namedMarkerArgs returns true if all arguments except the first one are `key=value`
+func namedMarkerArgs(args []string) bool {
+	if len(args) < 2 {
+		return false
+	}
+	for _, arg := range args[1:] {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 || !isMarkerIdent(strings.TrimSpace(kv[0])) {
+			return false
+		}
+	}
+	return true
+}
+
+// isMarkerIdent returns true for the argument name
+func isMarkerIdent(text string) bool {
+	if len(text) == 0 {
+		return false
+	}
+	for _, c := range text {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// addMarkerArg adds the argument value `value`, `"quoted value"`, `a;b;c` or `{a,b,c}`
+func addMarkerArg(anno *AnnotationInfo, key, arg string) error {
+	arg = strings.TrimSpace(arg)
+	switch {
+	case strings.HasPrefix(arg, "{"):
+		if !strings.HasSuffix(arg, "}") {
+			return fmt.Errorf("unterminated list %v", arg)
+		}
+		items, err := splitMarkerArgs(arg[1 : len(arg)-1])
+		if err != nil {
+			return err
+		}
+		return addMarkerList(anno, key, arg, items)
+	case strings.HasPrefix(arg, "\"") || strings.HasPrefix(arg, "`"):
+		value, err := unquoteMarkerValue(arg)
+		if err != nil {
+			return err
+		}
+		anno.add(key, value)
+	case strings.Contains(arg, ";"):
+		return addMarkerList(anno, key, arg, strings.Split(arg, ";"))
+	default:
+		anno.add(key, arg)
+	}
+	return nil
+}
+
+// addMarkerList adds the list argument, the parameter value is the raw argument
+func addMarkerList(anno *AnnotationInfo, key, arg string, items []string) error {
+	values := []string{}
+	for _, item := range items {
+		if item = strings.TrimSpace(item); len(item) == 0 {
+			continue
+		}
+		value, err := unquoteMarkerValue(item)
+		if err != nil {
+			return err
+		}
+		values = append(values, value)
+	}
+	anno.Params[key] = arg
+	anno.Values[key] = append(anno.Values[key], values...)
+	return nil
+}
+
+// unquoteMarkerValue unquotes the quoted value
+func unquoteMarkerValue(value string) (string, error) {
+	if !strings.HasPrefix(value, "\"") && !strings.HasPrefix(value, "`") {
+		return value, nil
+	}
+	end, err := quotedEnd(value, 0)
+	if err != nil {
+		return "", err
+	}
+	if end != len(value) {
+		return "", fmt.Errorf("unexpected text after the quoted value %v", value)
+	}
+	return strconv.Unquote(value)
+}
+
+// splitMarkerArgs splits the arguments by the comma outside of the quoted values and lists
+func splitMarkerArgs(text string) ([]string, error) {
+	result := []string{}
+	depth, start := 0, 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '"', '`':
+			end, err := quotedEnd(text, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end - 1
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				result = append(result, text[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unterminated list")
+	}
+	return append(result, text[start:]), nil
+}
+
+// markerComments returns the nearest comment group between the previous declaration and the doc comment
+// of the type. The kubebuilder markers of the type are usually declared in the comment group separated
+// by the empty line above the doc comment. The comments on the line of the previous declaration end are
+// the line comments of the previous declaration. For the type specification in the grouped declaration
+// `type ( ... )` the previous position is the left parenthesis or the end of the previous specification.
+func markerComments(fset *token.FileSet, file *ast.File, prev token.Pos, doc *ast.CommentGroup, pos token.Pos) *ast.CommentGroup {
+	anchor := pos
+	if doc != nil {
+		anchor = doc.Pos()
+	}
+	prevLine := fset.Position(prev).Line
+	var result *ast.CommentGroup
+	for _, c := range file.Comments {
+		if c.Pos() <= prev || fset.Position(c.Pos()).Line == prevLine {
+			continue
+		}
+		if c.End() >= anchor {
+			break
+		}
+		result = c
+	}
+	return result
+}
@@ -0,0 +1,132 @@
+package gondex
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarkerAnnotationParser(t *testing.T) {
+	tests := []struct {
+		text   string
+		name   string
+		params map[string]string
+		values map[string][]string
+		err    string
+	}{
+		{text: "// +kubebuilder:object:root", name: "kubebuilder:object:root", params: map[string]string{}, values: map[string][]string{}},
+		{text: "//+kubebuilder:validation:Minimum=1", name: "kubebuilder:validation:Minimum", params: map[string]string{"value": "1"}, values: map[string][]string{"value": {"1"}}},
+		{text: "// +kubebuilder:validation:Enum=a;b;c", name: "kubebuilder:validation:Enum", params: map[string]string{"value": "a;b;c"}, values: map[string][]string{"value": {"a", "b", "c"}}},
+		{text: "// +kubebuilder:validation:Enum={\"a,b\",c}", name: "kubebuilder:validation:Enum", params: map[string]string{"value": "{\"a,b\",c}"}, values: map[string][]string{"value": {"a,b", "c"}}},
+		{text: "// +kubebuilder:validation:Pattern=`^[a-z]+;$`", name: "kubebuilder:validation:Pattern", params: map[string]string{"value": "^[a-z]+;$"}, values: map[string][]string{"value": {"^[a-z]+;$"}}},
+		{text: "// +kubebuilder:default:=\"a\"", name: "kubebuilder:default", params: map[string]string{"value": "a"}, values: map[string][]string{"value": {"a"}}},
+		{text: "// +kubebuilder:resource:scope=Cluster", name: "kubebuilder:resource", params: map[string]string{"scope": "Cluster"}, values: map[string][]string{"scope": {"Cluster"}}},
+		{text: "// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list", name: "kubebuilder:rbac",
+			params: map[string]string{"groups": "apps", "resources": "deployments", "verbs": "get;list"},
+			values: map[string][]string{"groups": {"apps"}, "resources": {"deployments"}, "verbs": {"get", "list"}}},
+		{text: "// +custom:marker:first=1,second=\"x,y\"", name: "custom:marker",
+			params: map[string]string{"first": "1", "second": "x,y"},
+			values: map[string][]string{"first": {"1"}, "second": {"x,y"}}},
+		{text: "// +kubebuilder:validation:Enum={a,b", err: "unterminated list"},
+		{text: "// +kubebuilder:rbac:groups=apps,verbs", err: "invalid argument"},
+		{text: "// +kubebuilder:object:root text", err: "unexpected text"},
+		{text: "// + text"},
+		{text: "// 1+1=2"},
+	}
+
+	parser := NewMarkerAnnotationParser()
+	for _, test := range tests {
+		anno, err := parser.Parse(test.text)
+		if len(test.err) > 0 {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				panic(fmt.Errorf("%q expected error %v but was %v", test.text, test.err, err))
+			}
+			continue
+		}
+		if err != nil {
+			panic(fmt.Errorf("%q unexpected error %v", test.text, err))
+		}
+		if len(test.name) == 0 {
+			if anno != nil {
+				panic(fmt.Errorf("%q is not marker but was %v", test.text, anno.Name))
+			}
+			continue
+		}
+		if anno == nil || anno.Name != test.name || !reflect.DeepEqual(anno.Params, test.params) || !reflect.DeepEqual(anno.Values, test.values) {
+			panic(fmt.Errorf("%q expected %v %v %v but was %v", test.text, test.name, test.params, test.values, anno))
+		}
+	}
+
+	anno, _ := NewMarkerAnnotationParser("custom:marker").Parse("// +custom:marker:first=1")
+	if anno == nil || anno.Name != "custom:marker" || anno.Params["first"] != "1" {
+		panic(fmt.Errorf("wrong named marker %v", anno))
+	}
+}
+
+func TestMarkerMode(t *testing.T) {
+	config := CreateDefaultConfig()
+	config.MarkerMode = true
+	indexer := CreateIndexer(config)
+	if _, err := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/markers"); err != nil {
+		panic(err)
+	}
+
+	prefix := "github.com/go-gluon/gondex/internal/test/markers."
+	s := indexer.Struct(prefix + "Memcached")
+	expected := []string{
+		"kubebuilder:object:root",
+		"kubebuilder:subresource:status",
+		"kubebuilder:printcolumn",
+		"kubebuilder:rbac",
+		"kubebuilder:resource",
+	}
+	if names := annotationNames(s.Annotations()); !reflect.DeepEqual(names, expected) {
+		panic(fmt.Errorf("wrong markers %v", names))
+	}
+	if s.Annotation("kubebuilder:printcolumn").Params["JSONPath"] != ".metadata.creationTimestamp" {
+		panic(fmt.Errorf("wrong printcolumn marker %v", s.Annotation("kubebuilder:printcolumn")))
+	}
+	if doc := s.Doc().Text(); doc != "Memcached is the Schema for the memcacheds API\n" {
+		panic(fmt.Errorf("wrong doc %q", doc))
+	}
+	if pkg := indexer.Package("github.com/go-gluon/gondex/internal/test/markers"); pkg.Annotation("groupName").Params["value"] != "example.io" {
+		panic(fmt.Errorf("package marker not found"))
+	}
+
+	fields := s.FieldStructInfo().Fields()
+	if len(fields["Size"].AnnotationsPrefixed("kubebuilder:validation")) != 2 {
+		panic(fmt.Errorf("wrong field markers %v", fields["Size"].Annotations()))
+	}
+	enum := &struct {
+		Value []string `anno:"value"`
+	}{}
+	if err := fields["Mode"].Annotation("kubebuilder:validation:Enum").Decode(enum); err != nil || !reflect.DeepEqual(enum.Value, []string{"a", "b", "c"}) {
+		panic(fmt.Errorf("wrong enum marker %v %v", enum, err))
+	}
+	if fields["Client"].Annotation("gluon:Inject") == nil {
+		panic(fmt.Errorf("default annotation not found in the marker mode"))
+	}
+
+	markers := map[string][]string{}
+	for _, name := range []string{"Config", "Webhook", "Hook", "Plain"} {
+		markers[name] = annotationNames(indexer.Struct(prefix + name).Annotations())
+	}
+	expectedMarkers := map[string][]string{
+		"Config":  {"kubebuilder:object:root"},
+		"Webhook": {"kubebuilder:object:root", "kubebuilder:subresource:status"},
+		"Hook":    {"kubebuilder:object:generate", "kubebuilder:skip"},
+		"Plain":   {},
+	}
+	if !reflect.DeepEqual(markers, expectedMarkers) {
+		panic(fmt.Errorf("wrong markers of the types %v", markers))
+	}
+
+	objects := []string{prefix + "Config", prefix + "Hook", prefix + "Memcached", prefix + "MemcachedList", prefix + "Webhook"}
+	if ids := ids(indexer.FindByAnnotationPrefix("kubebuilder:object")); !reflect.DeepEqual(ids, objects) {
+		panic(fmt.Errorf("wrong elements by annotation prefix %v", ids))
+	}
+	if len(indexer.FindByAnnotationPrefix("kubebuilder:obj")) != 0 {
+		panic(fmt.Errorf("annotation prefix must match the whole name part"))
+	}
+}
@@ -0,0 +1,304 @@
+package gondex
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	javaAnnotationRegex      = regexp.MustCompile(`^//\s*@([A-Za-z_][0-9A-Za-z_\.]*)`)
+	directiveAnnotationRegex = regexp.MustCompile(`^//([a-z0-9]+):([0-9A-Za-z_\.\-]+)`)
+)
+
+// AnnotationParser parses the annotation from the comment
+type AnnotationParser interface {
+	// Parse parses the comment text with the `//` prefix. Returns nil if the comment is not
+	// an annotation and *AnnotationSyntaxError for the annotation with invalid syntax.
+	Parse(text string) (*AnnotationInfo, error)
+}
+
+// AnnotationSyntaxError represents syntax error at the offset of the annotation comment
+type AnnotationSyntaxError struct {
+	Annotation string
+	Offset     int
+	Msg        string
+}
+
+func (e *AnnotationSyntaxError) Error() string {
+	return fmt.Sprintf("annotation %v: offset %v: %v", e.Annotation, e.Offset, e.Msg)
+}
+
+// newAnnotationInfo creates annotation info without parameters
+func newAnnotationInfo(name string) *AnnotationInfo {
+	return &AnnotationInfo{
+		Name:   name,
+		Params: map[string]string{},
+		Values: map[string][]string{},
+	}
+}
+
+// defaultAnnotationParser parser for the `//name:name key=value key="quoted value" flag` annotations
+type defaultAnnotationParser struct {
+	r *regexp.Regexp
+}
+
+// NewDefaultAnnotationParser creates parser for the annotations `//name:name key=value key="quoted value" flag`.
+// The regex matches the annotation name including the `//` prefix.
+func NewDefaultAnnotationParser(r *regexp.Regexp) AnnotationParser {
+	if r == nil {
+		r = defaultAnnotationRegex
+	}
+	return &defaultAnnotationParser{r: r}
+}
+
+// Parse parses the annotation
+func (p *defaultAnnotationParser) Parse(text string) (*AnnotationInfo, error) {
+	sm := p.r.FindString(text)
+	if len(sm) == 0 {
+		return nil, nil
+	}
+
+	// annotation name must be followed by the parameters or end of the comment
+	params := strings.TrimPrefix(text, sm)
+	if len(params) > 0 && !unicode.IsSpace(rune(params[0])) {
+		return nil, nil
+	}
+
+	// go toolchain directives `//go:generate`, `//go:build` are not annotations
+	name := strings.TrimSuffix(strings.TrimPrefix(sm, "//"), " ")
+	if strings.HasPrefix(name, "go:") {
+		return nil, nil
+	}
+
+	anno := newAnnotationInfo(name)
+	if offset, err := parseAnnotationParams(anno, params); err != nil {
+		return anno, &AnnotationSyntaxError{Annotation: anno.Name, Offset: len(sm) + offset, Msg: err.Error()}
+	}
+	return anno, nil
+}
+
+// javaAnnotationParser parser for the `// @Route(path="/x", method=GET)` annotations
+type javaAnnotationParser struct{}
+
+// NewJavaAnnotationParser creates parser for the Java-like annotations `// @Route(path="/x", method={GET, POST})`.
+// The single value without the name `// @Route("/x")` is stored as the parameter `value`
+// and the array values `{a, b}` as the list of values.
+func NewJavaAnnotationParser() AnnotationParser {
+	return &javaAnnotationParser{}
+}
+
+// Parse parses the annotation
+func (p *javaAnnotationParser) Parse(text string) (*AnnotationInfo, error) {
+	m := javaAnnotationRegex.FindStringSubmatch(text)
+	if m == nil {
+		return nil, nil
+	}
+	anno := newAnnotationInfo(m[1])
+
+	s := &scanner{text: text, pos: len(m[0])}
+	if s.peek() != '(' {
+		if s.pos < len(text) && !unicode.IsSpace(rune(text[s.pos])) {
+			return nil, nil
+		}
+		return anno, nil
+	}
+	s.pos++
+
+	for {
+		s.skipSpaces()
+		if s.peek() == ')' {
+			s.pos++
+			break
+		}
+
+		// named or single value
+		start := s.pos
+		name := "value"
+		if ident := s.ident(); len(ident) > 0 {
+			s.skipSpaces()
+			if s.peek() == '=' {
+				s.pos++
+				name = ident
+			} else {
+				s.pos = start
+			}
+		}
+
+		s.skipSpaces()
+		if s.peek() == '{' {
+			s.pos++
+			anno.Values[name] = []string{}
+			for {
+				s.skipSpaces()
+				if s.peek() == '}' {
+					s.pos++
+					break
+				}
+				value, err := s.value(",}")
+				if err != nil {
+					return anno, s.error(anno, err)
+				}
+				anno.add(name, value)
+				s.skipSpaces()
+				if s.peek() == ',' {
+					s.pos++
+				}
+			}
+		} else {
+			value, err := s.value(",)")
+			if err != nil {
+				return anno, s.error(anno, err)
+			}
+			anno.add(name, value)
+		}
+
+		s.skipSpaces()
+		switch s.peek() {
+		case ',':
+			s.pos++
+		case ')':
+		default:
+			return anno, s.error(anno, fmt.Errorf("expected , or )"))
+		}
+	}
+
+	s.skipSpaces()
+	if s.pos < len(text) {
+		return anno, s.error(anno, fmt.Errorf("unexpected text after the annotation"))
+	}
+	return anno, nil
+}
+
+// directiveAnnotationParser parser for the `//go:generate command args` directives
+type directiveAnnotationParser struct {
+	prefixes map[string]struct{}
+}
+
+// NewDirectiveAnnotationParser creates parser for the directives `//go:generate command arg "quoted arg"`.
+// The directive has no space after `//`, the arguments are stored in AnnotationInfo.Args.
+// Only directives with the prefixes are parsed (for example `go`), all directives for no prefixes.
+func NewDirectiveAnnotationParser(prefixes ...string) AnnotationParser {
+	p := &directiveAnnotationParser{prefixes: map[string]struct{}{}}
+	for _, prefix := range prefixes {
+		p.prefixes[prefix] = struct{}{}
+	}
+	return p
+}
+
+// Parse parses the annotation
+func (p *directiveAnnotationParser) Parse(text string) (*AnnotationInfo, error) {
+	m := directiveAnnotationRegex.FindStringSubmatch(text)
+	if m == nil {
+		return nil, nil
+	}
+	if _, e := p.prefixes[m[1]]; len(p.prefixes) > 0 && !e {
+		return nil, nil
+	}
+	rest := text[len(m[0]):]
+	if len(rest) > 0 && !unicode.IsSpace(rune(rest[0])) {
+		return nil, nil
+	}
+
+	anno := newAnnotationInfo(m[1] + ":" + m[2])
+	anno.Args = []string{}
+	s := &scanner{text: text, pos: len(m[0])}
+	for {
+		s.skipSpaces()
+		if s.pos >= len(text) {
+			return anno, nil
+		}
+		value, err := s.value("")
+		if err != nil {
+			return anno, s.error(anno, err)
+		}
+		anno.Args = append(anno.Args, value)
+	}
+}
+
+// multiAnnotationParser composite parser
+type multiAnnotationParser struct {
+	parsers []AnnotationParser
+}
+
+// MultiAnnotationParser creates parser which uses the first parser which recognize the annotation
+func MultiAnnotationParser(parsers ...AnnotationParser) AnnotationParser {
+	return &multiAnnotationParser{parsers: parsers}
+}
+
+// Parse parses the annotation
+func (p *multiAnnotationParser) Parse(text string) (*AnnotationInfo, error) {
+	for _, parser := range p.parsers {
+		anno, err := parser.Parse(text)
+		if anno != nil || err != nil {
+			return anno, err
+		}
+	}
+	return nil, nil
+}
+
+// scanner simple scanner of the annotation text
+type scanner struct {
+	text string
+	pos  int
+}
+
+// peek returns current character or 0 for the end of the text
+func (s *scanner) peek() byte {
+	if s.pos >= len(s.text) {
+		return 0
+	}
+	return s.text[s.pos]
+}
+
+// skipSpaces skips all spaces
+func (s *scanner) skipSpaces() {
+	for s.pos < len(s.text) && unicode.IsSpace(rune(s.text[s.pos])) {
+		s.pos++
+	}
+}
+
+// ident reads identifier
+func (s *scanner) ident() string {
+	start := s.pos
+	for s.pos < len(s.text) {
+		c := rune(s.text[s.pos])
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' && c != '-' && c != '.' {
+			break
+		}
+		s.pos++
+	}
+	return s.text[start:s.pos]
+}
+
+// value reads quoted value or bare value up to the space or one of the end characters
+func (s *scanner) value(end string) (string, error) {
+	if c := s.peek(); c == '"' || c == '`' {
+		e, err := quotedEnd(s.text, s.pos)
+		if err != nil {
+			return "", err
+		}
+		value, err := strconv.Unquote(s.text[s.pos:e])
+		if err != nil {
+			return "", err
+		}
+		s.pos = e
+		return value, nil
+	}
+
+	start := s.pos
+	for s.pos < len(s.text) && !unicode.IsSpace(rune(s.text[s.pos])) && !strings.ContainsRune(end, rune(s.text[s.pos])) {
+		s.pos++
+	}
+	if start == s.pos {
+		return "", fmt.Errorf("missing value")
+	}
+	return s.text[start:s.pos], nil
+}
+
+// error creates syntax error at the current position
+func (s *scanner) error(anno *AnnotationInfo, err error) error {
+	return &AnnotationSyntaxError{Annotation: anno.Name, Offset: s.pos, Msg: err.Error()}
+}
@@ -0,0 +1,91 @@
+package gondex
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJavaAnnotationParser(t *testing.T) {
+	tests := []struct {
+		text   string
+		name   string
+		values map[string][]string
+		err    string
+	}{
+		{text: "// @Inject", name: "Inject", values: map[string][]string{}},
+		{text: "// @Inject()", name: "Inject", values: map[string][]string{}},
+		{text: "// @Route(\"/x\")", name: "Route", values: map[string][]string{"value": {"/x"}}},
+		{text: "// @Route(path=\"/x\", method=GET)", name: "Route", values: map[string][]string{"path": {"/x"}, "method": {"GET"}}},
+		{text: "//@Route(method={GET, \"POST\"})", name: "Route", values: map[string][]string{"method": {"GET", "POST"}}},
+		{text: "// @Route(path=\"/x)", err: "unterminated quoted string"},
+		{text: "// @Route(path=/x", err: "expected , or )"},
+		{text: "// @Route(path=) x", err: "missing value"},
+		{text: "// @Route() x", err: "unexpected text"},
+		{text: "// email@example.com"},
+		{text: "// @Route: text"},
+	}
+
+	parser := NewJavaAnnotationParser()
+	for _, test := range tests {
+		anno, err := parser.Parse(test.text)
+		if len(test.err) > 0 {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				panic(fmt.Errorf("%q expected error %v but was %v", test.text, test.err, err))
+			}
+			continue
+		}
+		if err != nil {
+			panic(fmt.Errorf("%q unexpected error %v", test.text, err))
+		}
+		if len(test.name) == 0 {
+			if anno != nil {
+				panic(fmt.Errorf("%q is not annotation but was %v", test.text, anno.Name))
+			}
+			continue
+		}
+		if anno == nil || anno.Name != test.name || !reflect.DeepEqual(anno.Values, test.values) {
+			panic(fmt.Errorf("%q expected %v %v but was %v", test.text, test.name, test.values, anno))
+		}
+	}
+}
+
+func TestDirectiveAnnotationParser(t *testing.T) {
+	anno, err := NewDirectiveAnnotationParser().Parse("//go:generate stringer -type=Pill \"quoted arg\"")
+	if err != nil || anno == nil || anno.Name != "go:generate" || !reflect.DeepEqual(anno.Args, []string{"stringer", "-type=Pill", "quoted arg"}) {
+		panic(fmt.Errorf("wrong directive %v %v", anno, err))
+	}
+	if anno, _ := NewDirectiveAnnotationParser("go").Parse("//lint:ignore"); anno != nil {
+		panic(fmt.Errorf("directive with other prefix %v", anno))
+	}
+	if anno, _ := NewDirectiveAnnotationParser().Parse("// go:generate"); anno != nil {
+		panic(fmt.Errorf("directive with space %v", anno))
+	}
+}
+
+func TestAnnotationParserConfig(t *testing.T) {
+	config := CreateDefaultConfig()
+	config.AnnotationParser = MultiAnnotationParser(NewJavaAnnotationParser(), NewMarkerAnnotationParser(), NewDirectiveAnnotationParser("go"))
+	indexer := CreateIndexer(config)
+	if _, err := indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/parsers"); err != nil {
+		panic(err)
+	}
+
+	s := indexer.Structs()["github.com/go-gluon/gondex/internal/test/parsers.Service"]
+	if s == nil {
+		panic(fmt.Errorf("struct Service not found"))
+	}
+	if names := annotationNames(s.Annotations()); !reflect.DeepEqual(names, []string{"Route", "kubebuilder:validation:Minimum", "go:generate"}) {
+		panic(fmt.Errorf("wrong annotations %v", names))
+	}
+	if route := s.Annotation("Route"); !reflect.DeepEqual(route.List("method"), []string{"GET", "POST"}) || route.Position().Line != 4 {
+		panic(fmt.Errorf("wrong route annotation %v", route))
+	}
+	if doc := s.Doc().Text(); doc != "Service service with the different annotation syntax\n" {
+		panic(fmt.Errorf("wrong doc %q", doc))
+	}
+	if len(indexer.FindStructsByAnnotation("Route")) != 1 {
+		panic(fmt.Errorf("struct not found by annotation"))
+	}
+}
@@ -0,0 +1,55 @@
+package gondex
+
+import (
+	"sort"
+)
+
+// SortById sorts the elements by id in place
+func SortById[T interface{ Id() string }](items []T) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Id() < items[j].Id()
+	})
+}
+
+// SortByPosition sorts the elements in place by the source position (file name, line and column).
+// Elements with the same position are sorted by id.
+func SortByPosition[T Element](items []T) {
+	sort.SliceStable(items, func(i, j int) bool {
+		pi, pj := items[i].Position(), items[j].Position()
+		if pi.Filename != pj.Filename {
+			return pi.Filename < pj.Filename
+		}
+		if pi.Line != pj.Line {
+			return pi.Line < pj.Line
+		}
+		if pi.Column != pj.Column {
+			return pi.Column < pj.Column
+		}
+		return items[i].Id() < items[j].Id()
+	})
+}
+
+// copyOf returns copy of the list, nil for the nil list
+func copyOf[T any](items []T) []T {
+	if items == nil {
+		return nil
+	}
+	return append([]T{}, items...)
+}
+
+// sortedById returns values of the map sorted by id
+func sortedById[T interface{ Id() string }](m map[string]T) []T {
+	result := make([]T, 0, len(m))
+	for _, v := range m {
+		result = append(result, v)
+	}
+	SortById(result)
+	return result
+}
+
+// sortIndex sorts all lists of the annotation index by id
+func sortIndex[T interface{ Id() string }](index map[string][]T) {
+	for _, items := range index {
+		SortById(items)
+	}
+}
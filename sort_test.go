@@ -0,0 +1,64 @@
+package gondex
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestSortedResults(t *testing.T) {
+	patterns := []string{
+		"github.com/go-gluon/gondex/internal/test/methods",
+		"github.com/go-gluon/gondex/internal/test/annotations",
+		"github.com/go-gluon/gondex/internal/test/inject",
+	}
+	reversed := []string{patterns[2], patterns[1], patterns[0]}
+
+	var expected []string
+	for _, p := range [][]string{patterns, reversed} {
+		indexer := CreateDefaultIndexer()
+		_, _ = indexer.LoadPattern(p...)
+
+		result := []string{}
+		for _, e := range indexer.FindByAnnotation("test:route") {
+			result = append(result, e.Id())
+		}
+		if !sort.StringsAreSorted(result) || len(result) != 4 {
+			panic(fmt.Errorf("elements are not sorted by id %v", result))
+		}
+		if expected != nil && fmt.Sprint(expected) != fmt.Sprint(result) {
+			panic(fmt.Errorf("different order for the load order %v %v", expected, result))
+		}
+		expected = result
+
+		structs := []string{}
+		for _, s := range indexer.SortedStructs() {
+			structs = append(structs, s.Id())
+		}
+		if !sort.StringsAreSorted(structs) || len(structs) != len(indexer.Structs()) {
+			panic(fmt.Errorf("structs are not sorted by id %v", structs))
+		}
+	}
+}
+
+func TestSortByPosition(t *testing.T) {
+	indexer := CreateDefaultIndexer()
+	_, _ = indexer.LoadPattern("github.com/go-gluon/gondex/internal/test/annotations")
+
+	items := indexer.FindStructsByAnnotation("test:route")
+	if len(items) != 2 || items[0].Name() != "Multi" {
+		panic(fmt.Errorf("structs are not sorted by id %v", items))
+	}
+	SortByPosition(items)
+	if items[0].Name() != "Route" || items[1].Name() != "Multi" {
+		panic(fmt.Errorf("structs are not sorted by position %v", items))
+	}
+	if again := indexer.FindStructsByAnnotation("test:route"); again[0].Name() != "Multi" {
+		panic(fmt.Errorf("sort by position modified the index %v", again))
+	}
+	elements := indexer.FindByAnnotation("test:route")
+	SortByPosition(elements)
+	if again := indexer.FindByAnnotation("test:route"); again[0].Id() > again[1].Id() {
+		panic(fmt.Errorf("sort by position modified the index %v", again))
+	}
+}